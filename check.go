@@ -0,0 +1,134 @@
+package must
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Check runs fn and returns the first assertion made by fn on the calling goroutine that failed, as a *Failure.
+// It returns nil if all assertions passed.
+// Failures inside Check are not passed to the failure handlers and do not trigger the failure policy,
+// the failed assertion returns to fn instead. Goroutines started by fn use the process-wide policy.
+// Check does not install anything on the goroutine, so it adds almost no cost to assertions that pass.
+func Check(fn func()) (err error) {
+	start := checkFailures.Load()
+	activeChecks.Add(1)
+	defer func() {
+		activeChecks.Add(-1)
+		// Only look up the goroutine when an assertion failed inside a Check since fn started
+		if checkFailures.Load() != start {
+			if f := takeChecked(goroutineID(), start); f != nil {
+				err = f
+			}
+		}
+	}()
+
+	fn()
+	return nil
+}
+
+var (
+	// activeChecks counts the Check calls in progress on all goroutines,
+	// so that failures and scopes skip looking for an enclosing Check when there is none.
+	activeChecks atomic.Int64
+	// checkFailures counts the failures recorded inside Check, it orders them against the start of each Check.
+	checkFailures atomic.Int64
+
+	checked      = map[uint64][]checkedFailure{}
+	checkedMutex sync.Mutex
+)
+
+// checkFunction is the name of Check as reported in stack frames.
+const checkFunction = modulePath + ".Check"
+
+// checkedFailure is a failure recorded inside a Check that has not returned yet.
+type checkedFailure struct {
+	position int   // Stack position of the innermost Check frame, counted from the bottom of the stack
+	seq      int64 // Value of checkFailures after recording
+	failure  *Failure
+}
+
+// enclosingCheck returns the position of the innermost Check frame on the calling goroutine, counted
+// from the bottom of the stack, or 0 if the goroutine is not inside Check or if inner is a scope
+// installed inside that Check, which then takes precedence.
+func enclosingCheck(inner *scope) int {
+	if activeChecks.Load() == 0 {
+		return 0
+	}
+	position, depth := checkFrames()
+	if inner != nil && inner.checks >= depth {
+		return 0
+	}
+	return position
+}
+
+// checkFrames returns the position of the innermost Check frame on the calling goroutine,
+// counted from the bottom of the stack, and the number of Check frames. Both are 0 outside Check.
+func checkFrames() (position, depth int) {
+	pcs := make([]uintptr, 64)
+	for {
+		n := runtime.Callers(1, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+			break
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+
+	var index int
+	frames := runtime.CallersFrames(pcs)
+	for i := 0; ; i++ {
+		frame, more := frames.Next()
+		if frame.Function == checkFunction {
+			if depth == 0 {
+				index = i
+			}
+			depth++
+		}
+		if !more {
+			if depth == 0 {
+				return 0, 0
+			}
+			return i + 1 - index, depth
+		}
+	}
+}
+
+// recordChecked records f for the Check at the given stack position, unless it already has a failure.
+func recordChecked(f *Failure, position int) {
+	checkedMutex.Lock()
+	defer checkedMutex.Unlock()
+
+	for _, c := range checked[f.Goroutine] {
+		if c.position == position {
+			return
+		}
+	}
+	checked[f.Goroutine] = append(checked[f.Goroutine], checkedFailure{position, checkFailures.Add(1), f})
+}
+
+// takeChecked removes the failures recorded on goroutine gid since start and returns the first one, or nil.
+// The Check calling it is the innermost one on the goroutine, failures recorded since it started
+// belong to it or to Check calls nested in it, which have already returned.
+func takeChecked(gid uint64, start int64) *Failure {
+	checkedMutex.Lock()
+	defer checkedMutex.Unlock()
+
+	var first *Failure
+	var kept []checkedFailure
+	for _, c := range checked[gid] {
+		switch {
+		case c.seq <= start:
+			kept = append(kept, c)
+		case first == nil:
+			first = c.failure
+		}
+	}
+	if len(kept) == 0 {
+		delete(checked, gid)
+	} else {
+		checked[gid] = kept
+	}
+	return first
+}
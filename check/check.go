@@ -0,0 +1,601 @@
+// Package check provides the assertions of package must as functions that return an error instead of panicking.
+//
+// Each function returns nil when the assertion holds and a *must.Failure carrying the same details as
+// the must assertion otherwise. Failures are not passed to the must failure handlers and do not trigger
+// the failure policy, which makes the package suitable for validation code that must not panic.
+package check
+
+import (
+	"cmp"
+	"io/fs"
+	"time"
+
+	"github.com/slayer/must"
+)
+
+// NotNil returns an error if the given value is nil, including typed nil pointers.
+func NotNil(value any, message string) error {
+	return must.Check(func() { must.NotNil(value, message) })
+}
+
+// NoError returns an error if the given error is not nil.
+func NoError(err error, message string) error {
+	return must.Check(func() { must.NoError(err, message) })
+}
+
+// Error returns an error if the given error is nil.
+func Error(err error, message string) error {
+	return must.Check(func() { must.Error(err, message) })
+}
+
+// ErrorIs returns an error if err does not match target according to errors.Is.
+func ErrorIs(err, target error, message string) error {
+	return must.Check(func() { must.ErrorIs(err, target, message) })
+}
+
+// ErrorAs returns the first error in the chain of err that is assignable to T,
+// or an error if there is none.
+func ErrorAs[T error](err error, message string) (T, error) {
+	var target T
+	checkErr := must.Check(func() { target = must.ErrorAs[T](err, message) })
+	return target, checkErr
+}
+
+// ErrorContains returns an error if err is nil or its message does not contain substring.
+func ErrorContains(err error, substring string, message string) error {
+	return must.Check(func() { must.ErrorContains(err, substring, message) })
+}
+
+// ErrorMatches returns an error if err is nil or its message does not match the regular expression pattern.
+func ErrorMatches(err error, pattern string, message string) error {
+	return must.Check(func() { must.ErrorMatches(err, pattern, message) })
+}
+
+// NotEqual returns an error if the given value is equal to the expected value.
+func NotEqual[T comparable](expected, value T, message string) error {
+	return must.Check(func() { must.NotEqual(expected, value, message) })
+}
+
+// Equal returns an error if the given value is not equal to the expected value.
+func Equal[T comparable](expected, value T, message string) error {
+	return must.Check(func() { must.Equal(expected, value, message) })
+}
+
+// True returns an error if the given value is false.
+func True(value bool, message string) error {
+	return must.Check(func() { must.True(value, message) })
+}
+
+// False returns an error if the given value is true.
+func False(value bool, message string) error {
+	return must.Check(func() { must.False(value, message) })
+}
+
+// NotZero returns an error if the given value is zero.
+func NotZero[T must.Number](value T, message string) error {
+	return must.Check(func() { must.NotZero(value, message) })
+}
+
+// GreaterThan returns an error if value is not greater than threshold.
+func GreaterThan[T cmp.Ordered](value, threshold T, message string) error {
+	return must.Check(func() { must.GreaterThan(value, threshold, message) })
+}
+
+// LessThan returns an error if value is not less than threshold.
+func LessThan[T cmp.Ordered](value, threshold T, message string) error {
+	return must.Check(func() { must.LessThan(value, threshold, message) })
+}
+
+// GreaterThanOrEqual returns an error if value is less than threshold.
+func GreaterThanOrEqual[T cmp.Ordered](value, threshold T, message string) error {
+	return must.Check(func() { must.GreaterThanOrEqual(value, threshold, message) })
+}
+
+// LessThanOrEqual returns an error if value is greater than threshold.
+func LessThanOrEqual[T cmp.Ordered](value, threshold T, message string) error {
+	return must.Check(func() { must.LessThanOrEqual(value, threshold, message) })
+}
+
+// Between returns an error if value is not within [low, high].
+func Between[T cmp.Ordered](value, low, high T, message string) error {
+	return must.Check(func() { must.Between(value, low, high, message) })
+}
+
+// InRange returns an error if value is not within the range from low to high, with the ends selected by bounds.
+func InRange[T cmp.Ordered](value, low, high T, bounds must.Bounds, message string) error {
+	return must.Check(func() { must.InRange(value, low, high, bounds, message) })
+}
+
+// NotEmpty returns an error if the given value is empty.
+func NotEmpty(value any, message string) error {
+	return must.Check(func() { must.NotEmpty(value, message) })
+}
+
+// Empty returns an error if the given value is not empty.
+func Empty(value any, message string) error {
+	return must.Check(func() { must.Empty(value, message) })
+}
+
+// Len returns an error if the given value does not have exactly n elements.
+func Len(value any, n int, message string) error {
+	return must.Check(func() { must.Len(value, n, message) })
+}
+
+// MinLen returns an error if the given value has fewer than n elements.
+func MinLen(value any, n int, message string) error {
+	return must.Check(func() { must.MinLen(value, n, message) })
+}
+
+// MaxLen returns an error if the given value has more than n elements.
+func MaxLen(value any, n int, message string) error {
+	return must.Check(func() { must.MaxLen(value, n, message) })
+}
+
+// HasPrefix returns an error if value does not start with prefix.
+func HasPrefix(value, prefix string, message string) error {
+	return must.Check(func() { must.HasPrefix(value, prefix, message) })
+}
+
+// HasSuffix returns an error if value does not end with suffix.
+func HasSuffix(value, suffix string, message string) error {
+	return must.Check(func() { must.HasSuffix(value, suffix, message) })
+}
+
+// StringContains returns an error if value does not contain substring.
+func StringContains(value, substring string, message string) error {
+	return must.Check(func() { must.StringContains(value, substring, message) })
+}
+
+// Matches returns an error if value does not match the regular expression pattern.
+func Matches(value, pattern string, message string) error {
+	return must.Check(func() { must.Matches(value, pattern, message) })
+}
+
+// ValidUTF8 returns an error if value is not valid UTF-8.
+func ValidUTF8(value string, message string) error {
+	return must.Check(func() { must.ValidUTF8(value, message) })
+}
+
+// EqualFold returns an error if value is not equal to expected under Unicode case folding.
+func EqualFold(expected, value string, message string) error {
+	return must.Check(func() { must.EqualFold(expected, value, message) })
+}
+
+// NoControlChars returns an error if value contains control characters.
+func NoControlChars(value string, message string) error {
+	return must.Check(func() { must.NoControlChars(value, message) })
+}
+
+// MaxRunes returns an error if value has more than n characters.
+func MaxRunes(value string, n int, message string) error {
+	return must.Check(func() { must.MaxRunes(value, n, message) })
+}
+
+// Contains returns an error if the given slice does not contain the specified value.
+func Contains[T comparable](slice []T, value T, message string) error {
+	return must.Check(func() { must.Contains(slice, value, message) })
+}
+
+// NotContains returns an error if the given slice contains the specified value.
+func NotContains[T comparable](slice []T, value T, message string) error {
+	return must.Check(func() { must.NotContains(slice, value, message) })
+}
+
+// IsNil returns an error if the given value is not nil.
+func IsNil(value any, message string) error {
+	return must.Check(func() { must.IsNil(value, message) })
+}
+
+// IsNotNil returns an error if the given value is nil.
+func IsNotNil(value any, message string) error {
+	return must.Check(func() { must.IsNotNil(value, message) })
+}
+
+// FileExists returns an error if the given path does not exist or is a directory.
+func FileExists(path string, message string) error {
+	return must.Check(func() { must.FileExists(path, message) })
+}
+
+// FileExistsFS is like FileExists for a file in fsys.
+func FileExistsFS(fsys fs.FS, name string, message string) error {
+	return must.Check(func() { must.FileExistsFS(fsys, name, message) })
+}
+
+// DirExists returns an error if the given path does not exist or is not a directory.
+func DirExists(path string, message string) error {
+	return must.Check(func() { must.DirExists(path, message) })
+}
+
+// DirExistsFS is like DirExists for a directory in fsys.
+func DirExistsFS(fsys fs.FS, name string, message string) error {
+	return must.Check(func() { must.DirExistsFS(fsys, name, message) })
+}
+
+// NotExists returns an error if something exists at the given path.
+func NotExists(path string, message string) error {
+	return must.Check(func() { must.NotExists(path, message) })
+}
+
+// NotExistsFS is like NotExists for a name in fsys.
+func NotExistsFS(fsys fs.FS, name string, message string) error {
+	return must.Check(func() { must.NotExistsFS(fsys, name, message) })
+}
+
+// IsRegularFile returns an error if the given path is not a regular file.
+func IsRegularFile(path string, message string) error {
+	return must.Check(func() { must.IsRegularFile(path, message) })
+}
+
+// IsRegularFileFS is like IsRegularFile for a file in fsys.
+func IsRegularFileFS(fsys fs.FS, name string, message string) error {
+	return must.Check(func() { must.IsRegularFileFS(fsys, name, message) })
+}
+
+// IsSymlink returns an error if the given path is not a symbolic link.
+func IsSymlink(path string, message string) error {
+	return must.Check(func() { must.IsSymlink(path, message) })
+}
+
+// IsSymlinkFS is like IsSymlink for a name in fsys.
+func IsSymlinkFS(fsys fs.FS, name string, message string) error {
+	return must.Check(func() { must.IsSymlinkFS(fsys, name, message) })
+}
+
+// FileMode returns an error if the permission bits of the given path are not perm.
+func FileMode(path string, perm fs.FileMode, message string) error {
+	return must.Check(func() { must.FileMode(path, perm, message) })
+}
+
+// FileModeFS is like FileMode for a file in fsys.
+func FileModeFS(fsys fs.FS, name string, perm fs.FileMode, message string) error {
+	return must.Check(func() { must.FileModeFS(fsys, name, perm, message) })
+}
+
+// FileSizeBetween returns an error if the size of the given file is not within [low, high] bytes.
+func FileSizeBetween(path string, low, high int64, message string) error {
+	return must.Check(func() { must.FileSizeBetween(path, low, high, message) })
+}
+
+// FileSizeBetweenFS is like FileSizeBetween for a file in fsys.
+func FileSizeBetweenFS(fsys fs.FS, name string, low, high int64, message string) error {
+	return must.Check(func() { must.FileSizeBetweenFS(fsys, name, low, high, message) })
+}
+
+// FileContains returns an error if the content of the given file does not contain substring.
+func FileContains(path string, substring string, message string) error {
+	return must.Check(func() { must.FileContains(path, substring, message) })
+}
+
+// FileContainsFS is like FileContains for a file in fsys.
+func FileContainsFS(fsys fs.FS, name string, substring string, message string) error {
+	return must.Check(func() { must.FileContainsFS(fsys, name, substring, message) })
+}
+
+// FileSHA256 returns an error if the SHA-256 checksum of the given file is not the hex-encoded sum.
+func FileSHA256(path string, sum string, message string) error {
+	return must.Check(func() { must.FileSHA256(path, sum, message) })
+}
+
+// FileSHA256FS is like FileSHA256 for a file in fsys.
+func FileSHA256FS(fsys fs.FS, name string, sum string, message string) error {
+	return must.Check(func() { must.FileSHA256FS(fsys, name, sum, message) })
+}
+
+// Readable returns an error if the given path cannot be opened for reading.
+func Readable(path string, message string) error {
+	return must.Check(func() { must.Readable(path, message) })
+}
+
+// ReadableFS is like Readable for a name in fsys.
+func ReadableFS(fsys fs.FS, name string, message string) error {
+	return must.Check(func() { must.ReadableFS(fsys, name, message) })
+}
+
+// Writable returns an error if the given path cannot be written to.
+func Writable(path string, message string) error {
+	return must.Check(func() { must.Writable(path, message) })
+}
+
+// TypeOf returns an error if the given value is not of type T.
+func TypeOf[T any](value any, message string) error {
+	return must.Check(func() { must.TypeOf[T](value, message) })
+}
+
+// TypeOfNot returns an error if the given value is of type T.
+func TypeOfNot[T any](value any, message string) error {
+	return must.Check(func() { must.TypeOfNot[T](value, message) })
+}
+
+// Same returns an error if expected and value do not refer to the same memory.
+func Same[T any](expected, value T, message string) error {
+	return must.Check(func() { must.Same(expected, value, message) })
+}
+
+// NotSame returns an error if expected and value refer to the same memory.
+func NotSame[T any](expected, value T, message string) error {
+	return must.Check(func() { must.NotSame(expected, value, message) })
+}
+
+// PointeeEqual returns an error if the pointers are nil or point to values that are not equal.
+func PointeeEqual[T comparable](expected, value *T, message string) error {
+	return must.Check(func() { must.PointeeEqual(expected, value, message) })
+}
+
+// PointsToSame returns an error if the pointers are nil or point to different memory.
+//
+// Deprecated: Use Same.
+func PointsToSame[T comparable](a, b *T, message string) error {
+	return must.Check(func() { must.PointsToSame(a, b, message) })
+}
+
+// PointsToNotSame returns an error if the pointers are nil or point to the same memory.
+//
+// Deprecated: Use NotSame.
+func PointsToNotSame[T comparable](a, b *T, message string) error {
+	return must.Check(func() { must.PointsToNotSame(a, b, message) })
+}
+
+// SliceHas returns an error if the given slice does not contain the specified value.
+func SliceHas[T comparable](slice []T, value T, message string) error {
+	return must.Check(func() { must.SliceHas(slice, value, message) })
+}
+
+// SliceNotHas returns an error if the given slice contains the specified value.
+func SliceNotHas[T comparable](slice []T, value T, message string) error {
+	return must.Check(func() { must.SliceNotHas(slice, value, message) })
+}
+
+// MapHas returns an error if the given map does not have the key.
+func MapHas[K comparable, V any](m map[K]V, key K, message string) error {
+	return must.Check(func() { must.MapHas(m, key, message) })
+}
+
+// MapNotHas returns an error if the given map has the key.
+func MapNotHas[K comparable, V any](m map[K]V, key K, message string) error {
+	return must.Check(func() { must.MapNotHas(m, key, message) })
+}
+
+// MapNotEmpty returns an error if the given map is empty.
+func MapNotEmpty[K comparable, V any](m map[K]V, message string) error {
+	return must.Check(func() { must.MapNotEmpty(m, message) })
+}
+
+// MapEmpty returns an error if the given map is not empty.
+func MapEmpty[K comparable, V any](m map[K]V, message string) error {
+	return must.Check(func() { must.MapEmpty(m, message) })
+}
+
+// MapHasAll returns an error listing the missing keys if m does not have every one of the given keys.
+func MapHasAll[K comparable, V any](m map[K]V, keys []K, message string) error {
+	return must.Check(func() { must.MapHasAll(m, keys, message) })
+}
+
+// MapValueEquals returns an error if m does not have key with a value equal to expected.
+func MapValueEquals[K, V comparable](m map[K]V, key K, expected V, message string) error {
+	return must.Check(func() { must.MapValueEquals(m, key, expected, message) })
+}
+
+// MapEqual returns an error listing the missing, extra and changed entries if value is not equal to expected.
+func MapEqual[K, V comparable](expected, value map[K]V, message string) error {
+	return must.Check(func() { must.MapEqual(expected, value, message) })
+}
+
+// MapKeysExactly returns an error if the keys of m are not exactly the given keys.
+func MapKeysExactly[K comparable, V any](m map[K]V, keys []K, message string) error {
+	return must.Check(func() { must.MapKeysExactly(m, keys, message) })
+}
+
+// MapLen returns an error if m does not have exactly n entries.
+func MapLen[K comparable, V any](m map[K]V, n int, message string) error {
+	return must.Check(func() { must.MapLen(m, n, message) })
+}
+
+// IsEmpty returns an error if the given slice is not empty.
+func IsEmpty[T comparable](slice []T, message string) error {
+	return must.Check(func() { must.IsEmpty(slice, message) })
+}
+
+// DeepEqual returns an error listing the differences if value is not deeply equal to expected.
+func DeepEqual(expected, value any, message string) error {
+	return must.Check(func() { must.DeepEqual(expected, value, message) })
+}
+
+// EqualText returns an error with a unified diff if the given text is not equal to the expected text.
+func EqualText(expected, value string, message string) error {
+	return must.Check(func() { must.EqualText(expected, value, message) })
+}
+
+// EqualBytes returns an error with a diff if the given byte slice is not equal to the expected one.
+func EqualBytes(expected, value []byte, message string) error {
+	return must.Check(func() { must.EqualBytes(expected, value, message) })
+}
+
+// InDelta returns an error if value is not within delta of expected.
+func InDelta[T must.Float](expected, value, delta T, message string) error {
+	return must.Check(func() { must.InDelta(expected, value, delta, message) })
+}
+
+// InEpsilon returns an error if the relative error between value and expected exceeds epsilon.
+func InEpsilon[T must.Float](expected, value, epsilon T, message string) error {
+	return must.Check(func() { must.InEpsilon(expected, value, epsilon, message) })
+}
+
+// WithinULPs returns an error if value is more than ulps representable values away from expected.
+func WithinULPs[T must.Float](expected, value T, ulps uint64, message string) error {
+	return must.Check(func() { must.WithinULPs(expected, value, ulps, message) })
+}
+
+// NotNaN returns an error if the given value is NaN.
+func NotNaN[T must.Float](value T, message string) error {
+	return must.Check(func() { must.NotNaN(value, message) })
+}
+
+// Finite returns an error if the given value is NaN or an infinity.
+func Finite[T must.Float](value T, message string) error {
+	return must.Check(func() { must.Finite(value, message) })
+}
+
+// InDeltaSlice returns an error if the slices differ in length or any element is not within delta.
+func InDeltaSlice[T must.Float](expected, value []T, delta T, message string) error {
+	return must.Check(func() { must.InDeltaSlice(expected, value, delta, message) })
+}
+
+// InEpsilonSlice returns an error if the slices differ in length or any element exceeds the relative error.
+func InEpsilonSlice[T must.Float](expected, value []T, epsilon T, message string) error {
+	return must.Check(func() { must.InEpsilonSlice(expected, value, epsilon, message) })
+}
+
+// Panics returns an error if fn returns normally.
+func Panics(fn func(), message string) error {
+	return must.Check(func() { must.Panics(fn, message) })
+}
+
+// NotPanics returns an error with the recovered value and stack if fn panics.
+func NotPanics(fn func(), message string) error {
+	return must.Check(func() { must.NotPanics(fn, message) })
+}
+
+// PanicsWithValue returns an error if fn does not panic with a value deeply equal to expected.
+func PanicsWithValue(expected any, fn func(), message string) error {
+	return must.Check(func() { must.PanicsWithValue(expected, fn, message) })
+}
+
+// PanicsWithError returns an error if fn does not panic with an error whose message equals expected.
+func PanicsWithError(expected string, fn func(), message string) error {
+	return must.Check(func() { must.PanicsWithError(expected, fn, message) })
+}
+
+// ElementsMatch returns an error if value does not have the same elements as expected, in any order.
+func ElementsMatch[T comparable](expected, value []T, message string) error {
+	return must.Check(func() { must.ElementsMatch(expected, value, message) })
+}
+
+// ElementsMatchFunc is like ElementsMatch with elements compared by eq.
+func ElementsMatchFunc[T any](expected, value []T, eq func(a, b T) bool, message string) error {
+	return must.Check(func() { must.ElementsMatchFunc(expected, value, eq, message) })
+}
+
+// SubsetOf returns an error if an element of value is not an element of set.
+func SubsetOf[T comparable](value, set []T, message string) error {
+	return must.Check(func() { must.SubsetOf(value, set, message) })
+}
+
+// SubsetOfFunc is like SubsetOf with elements compared by eq.
+func SubsetOfFunc[T any](value, set []T, eq func(a, b T) bool, message string) error {
+	return must.Check(func() { must.SubsetOfFunc(value, set, eq, message) })
+}
+
+// SupersetOf returns an error if an element of subset is not an element of value.
+func SupersetOf[T comparable](value, subset []T, message string) error {
+	return must.Check(func() { must.SupersetOf(value, subset, message) })
+}
+
+// SupersetOfFunc is like SupersetOf with elements compared by eq.
+func SupersetOfFunc[T any](value, subset []T, eq func(a, b T) bool, message string) error {
+	return must.Check(func() { must.SupersetOfFunc(value, subset, eq, message) })
+}
+
+// Disjoint returns an error if a and b have elements in common.
+func Disjoint[T comparable](a, b []T, message string) error {
+	return must.Check(func() { must.Disjoint(a, b, message) })
+}
+
+// DisjointFunc is like Disjoint with elements compared by eq.
+func DisjointFunc[T any](a, b []T, eq func(a, b T) bool, message string) error {
+	return must.Check(func() { must.DisjointFunc(a, b, eq, message) })
+}
+
+// SetEqual returns an error if value and expected do not have the same elements, ignoring order and repetitions.
+func SetEqual[T comparable](expected, value []T, message string) error {
+	return must.Check(func() { must.SetEqual(expected, value, message) })
+}
+
+// SetEqualFunc is like SetEqual with elements compared by eq.
+func SetEqualFunc[T any](expected, value []T, eq func(a, b T) bool, message string) error {
+	return must.Check(func() { must.SetEqualFunc(expected, value, eq, message) })
+}
+
+// Unique returns an error if value has duplicate elements.
+func Unique[T comparable](value []T, message string) error {
+	return must.Check(func() { must.Unique(value, message) })
+}
+
+// UniqueFunc is like Unique with elements compared by eq.
+func UniqueFunc[T any](value []T, eq func(a, b T) bool, message string) error {
+	return must.Check(func() { must.UniqueFunc(value, eq, message) })
+}
+
+// All returns an error if pred does not hold for every element of slice.
+func All[T any](slice []T, pred func(T) bool, message string, opts ...must.PredicateOption) error {
+	return must.Check(func() { must.All(slice, pred, message, opts...) })
+}
+
+// Any returns an error if pred holds for no element of slice.
+func Any[T any](slice []T, pred func(T) bool, message string, opts ...must.PredicateOption) error {
+	return must.Check(func() { must.Any(slice, pred, message, opts...) })
+}
+
+// None returns an error if pred holds for any element of slice.
+func None[T any](slice []T, pred func(T) bool, message string, opts ...must.PredicateOption) error {
+	return must.Check(func() { must.None(slice, pred, message, opts...) })
+}
+
+// MapAll returns an error if pred does not hold for every entry of m.
+func MapAll[K comparable, V any](m map[K]V, pred func(K, V) bool, message string, opts ...must.PredicateOption) error {
+	return must.Check(func() { must.MapAll(m, pred, message, opts...) })
+}
+
+// MapAny returns an error if pred holds for no entry of m.
+func MapAny[K comparable, V any](m map[K]V, pred func(K, V) bool, message string, opts ...must.PredicateOption) error {
+	return must.Check(func() { must.MapAny(m, pred, message, opts...) })
+}
+
+// MapNone returns an error if pred holds for any entry of m.
+func MapNone[K comparable, V any](m map[K]V, pred func(K, V) bool, message string, opts ...must.PredicateOption) error {
+	return must.Check(func() { must.MapNone(m, pred, message, opts...) })
+}
+
+// WithinDuration returns an error if value is more than delta away from expected.
+func WithinDuration(expected, value time.Time, delta time.Duration, message string) error {
+	return must.Check(func() { must.WithinDuration(expected, value, delta, message) })
+}
+
+// Before returns an error if value is not before threshold.
+func Before(value, threshold time.Time, message string) error {
+	return must.Check(func() { must.Before(value, threshold, message) })
+}
+
+// After returns an error if value is not after threshold.
+func After(value, threshold time.Time, message string) error {
+	return must.Check(func() { must.After(value, threshold, message) })
+}
+
+// NotZeroTime returns an error if value is the zero time.
+func NotZeroTime(value time.Time, message string) error {
+	return must.Check(func() { must.NotZeroTime(value, message) })
+}
+
+// InLocation returns an error if value is not in the location with the same name as loc.
+func InLocation(value time.Time, loc *time.Location, message string) error {
+	return must.Check(func() { must.InLocation(value, loc, message) })
+}
+
+// DurationBetween returns an error if d is not within [low, high].
+func DurationBetween(d, low, high time.Duration, message string) error {
+	return must.Check(func() { must.DurationBetween(d, low, high, message) })
+}
+
+// Monotonic returns an error if times are not in non-decreasing order.
+func Monotonic(times []time.Time, message string) error {
+	return must.Check(func() { must.Monotonic(times, message) })
+}
+
+// NotInFuture returns an error if value is after the current time of the must clock.
+func NotInFuture(value time.Time, message string) error {
+	return must.Check(func() { must.NotInFuture(value, message) })
+}
+
+// NotExpired returns an error if expiry is not after the current time of the must clock.
+func NotExpired(expiry time.Time, message string) error {
+	return must.Check(func() { must.NotExpired(expiry, message) })
+}
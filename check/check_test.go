@@ -0,0 +1,228 @@
+package check
+
+import (
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/slayer/must"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFailureValue tests the error returned by a failed check
+func TestFailureValue(t *testing.T) {
+
+	err := Equal(42, 43, "numbers differ")
+	require.Error(t, err)
+
+	var f *must.Failure
+	require.True(t, errors.As(err, &f))
+	assert.Equal(t, "Equal", f.Assertion)
+	assert.Equal(t, "numbers differ", f.Message)
+	assert.Equal(t, "expected 42 to be equal to 43", f.Details)
+	assert.Equal(t, 42, f.Expected)
+	assert.Equal(t, 43, f.Actual)
+	assert.Contains(t, f.File, "check_test.go")
+	assert.Contains(t, f.Function, "TestFailureValue")
+}
+
+// TestHandlersNotCalled tests that checks do not trigger the must failure handlers
+func TestHandlersNotCalled(t *testing.T) {
+
+	var called bool
+	unregister := must.RegisterHandler(func(*must.Failure) { called = true })
+	defer unregister()
+
+	assert.Error(t, True(false, "should fail"))
+	assert.False(t, called)
+}
+
+// TestErrorAs tests that ErrorAs returns the matched error
+func TestErrorAs(t *testing.T) {
+
+	pathErr := &os.PathError{Op: "open", Path: "x", Err: os.ErrNotExist}
+	got, err := ErrorAs[*os.PathError](pathErr, "msg")
+	require.NoError(t, err)
+	assert.Same(t, pathErr, got)
+
+	got, err = ErrorAs[*os.PathError](errors.New("boom"), "msg")
+	require.Error(t, err)
+	assert.Nil(t, got)
+}
+
+// lstatDirFS is an os.DirFS that reports symlinks without following them
+type lstatDirFS string
+
+func (d lstatDirFS) Open(name string) (fs.File, error) { return os.DirFS(string(d)).Open(name) }
+
+func (d lstatDirFS) Lstat(name string) (fs.FileInfo, error) {
+	return os.Lstat(filepath.Join(string(d), name))
+}
+
+// TestChecks tests the success and failure case of every check
+func TestChecks(t *testing.T) {
+
+	dir := t.TempDir()
+	file, err := os.CreateTemp(dir, "check-*")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	link := dir + "/link"
+	require.NoError(t, os.Symlink(file.Name(), link))
+	fsys := fstest.MapFS{"a.txt": {Data: []byte("a"), Mode: 0o644}}
+	const (
+		emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+		aSHA256     = "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"
+	)
+
+	now := time.Now()
+	later := now.Add(time.Minute)
+	absEqual := func(a, b int) bool { return a == b || a == -b }
+	positive := func(n int) bool { return n > 0 }
+	positiveEntry := func(_, v int) bool { return v > 0 }
+
+	value, other := "value", "other"
+	var nilPtr *string
+
+	tests := []struct {
+		name string
+		pass error
+		fail error
+	}{
+		{"NotNil", NotNil(&value, "msg"), NotNil(nilPtr, "msg")},
+		{"NoError", NoError(nil, "msg"), NoError(errors.New("boom"), "msg")},
+		{"Error", Error(errors.New("boom"), "msg"), Error(nil, "msg")},
+		{"ErrorIs", ErrorIs(os.ErrNotExist, os.ErrNotExist, "msg"), ErrorIs(errors.New("boom"), os.ErrNotExist, "msg")},
+		{"ErrorContains", ErrorContains(errors.New("boom"), "oo", "msg"), ErrorContains(errors.New("boom"), "x", "msg")},
+		{"ErrorMatches", ErrorMatches(errors.New("boom"), "^b", "msg"), ErrorMatches(errors.New("boom"), "^x", "msg")},
+		{"NotEqual", NotEqual(1, 2, "msg"), NotEqual(1, 1, "msg")},
+		{"Equal", Equal("a", "a", "msg"), Equal("a", "b", "msg")},
+		{"True", True(true, "msg"), True(false, "msg")},
+		{"False", False(false, "msg"), False(true, "msg")},
+		{"NotZero", NotZero(1, "msg"), NotZero(0, "msg")},
+		{"GreaterThan", GreaterThan(2, 1, "msg"), GreaterThan(1, 1, "msg")},
+		{"LessThan", LessThan(1, 2, "msg"), LessThan(2, 2, "msg")},
+		{"GreaterThanOrEqual", GreaterThanOrEqual(1, 1, "msg"), GreaterThanOrEqual(0, 1, "msg")},
+		{"LessThanOrEqual", LessThanOrEqual(1, 1, "msg"), LessThanOrEqual(2, 1, "msg")},
+		{"Between", Between(2, 1, 3, "msg"), Between(4, 1, 3, "msg")},
+		{"InRange", InRange(2, 1, 3, must.Exclusive, "msg"), InRange(3, 1, 3, must.Exclusive, "msg")},
+		{"InDelta", InDelta(1.0, 1.05, 0.1, "msg"), InDelta(1.0, 1.5, 0.1, "msg")},
+		{"InEpsilon", InEpsilon(100.0, 101.0, 0.05, "msg"), InEpsilon(100.0, 110.0, 0.05, "msg")},
+		{"WithinULPs", WithinULPs(1.0, 1.0, 0, "msg"), WithinULPs(1.0, 1.5, 1, "msg")},
+		{"NotNaN", NotNaN(1.0, "msg"), NotNaN(math.NaN(), "msg")},
+		{"Finite", Finite(1.0, "msg"), Finite(math.Inf(1), "msg")},
+		{"InDeltaSlice", InDeltaSlice([]float64{1}, []float64{1}, 0.1, "msg"), InDeltaSlice([]float64{1}, []float64{2}, 0.1, "msg")},
+		{"InEpsilonSlice", InEpsilonSlice([]float64{1}, []float64{1}, 0.1, "msg"), InEpsilonSlice([]float64{1}, []float64{2}, 0.1, "msg")},
+		{"NotEmpty", NotEmpty("a", "msg"), NotEmpty("", "msg")},
+		{"Empty", Empty("", "msg"), Empty("a", "msg")},
+		{"Len", Len([]int{1}, 1, "msg"), Len([]int{1}, 2, "msg")},
+		{"MinLen", MinLen([]int{1}, 1, "msg"), MinLen([]int{1}, 2, "msg")},
+		{"MaxLen", MaxLen([]int{1}, 1, "msg"), MaxLen([]int{1, 2}, 1, "msg")},
+		{"HasPrefix", HasPrefix("abc", "a", "msg"), HasPrefix("abc", "b", "msg")},
+		{"HasSuffix", HasSuffix("abc", "c", "msg"), HasSuffix("abc", "b", "msg")},
+		{"StringContains", StringContains("abc", "b", "msg"), StringContains("abc", "d", "msg")},
+		{"Matches", Matches("abc", "^a", "msg"), Matches("abc", "^b", "msg")},
+		{"ValidUTF8", ValidUTF8("abc", "msg"), ValidUTF8("\xff", "msg")},
+		{"EqualFold", EqualFold("abc", "ABC", "msg"), EqualFold("abc", "abd", "msg")},
+		{"NoControlChars", NoControlChars("abc", "msg"), NoControlChars("a\x00", "msg")},
+		{"MaxRunes", MaxRunes("abc", 3, "msg"), MaxRunes("abcd", 3, "msg")},
+		{"Contains", Contains([]int{1}, 1, "msg"), Contains([]int{1}, 2, "msg")},
+		{"NotContains", NotContains([]int{1}, 2, "msg"), NotContains([]int{1}, 1, "msg")},
+		{"IsNil", IsNil(nil, "msg"), IsNil(1, "msg")},
+		{"IsNotNil", IsNotNil(1, "msg"), IsNotNil(nil, "msg")},
+		{"FileExists", FileExists(file.Name(), "msg"), FileExists(dir, "msg")},
+		{"FileExistsFS", FileExistsFS(fsys, "a.txt", "msg"), FileExistsFS(fsys, "missing", "msg")},
+		{"DirExists", DirExists(dir, "msg"), DirExists(file.Name(), "msg")},
+		{"DirExistsFS", DirExistsFS(fsys, ".", "msg"), DirExistsFS(fsys, "a.txt", "msg")},
+		{"NotExists", NotExists(dir+"/missing", "msg"), NotExists(dir, "msg")},
+		{"NotExistsFS", NotExistsFS(fsys, "missing", "msg"), NotExistsFS(fsys, "a.txt", "msg")},
+		{"IsRegularFile", IsRegularFile(file.Name(), "msg"), IsRegularFile(dir, "msg")},
+		{"IsRegularFileFS", IsRegularFileFS(fsys, "a.txt", "msg"), IsRegularFileFS(fsys, ".", "msg")},
+		{"IsSymlink", IsSymlink(link, "msg"), IsSymlink(file.Name(), "msg")},
+		{"IsSymlinkFS", IsSymlinkFS(lstatDirFS(dir), "link", "msg"), IsSymlinkFS(fsys, "a.txt", "msg")},
+		{"FileMode", FileMode(file.Name(), 0o600, "msg"), FileMode(file.Name(), 0o644, "msg")},
+		{"FileModeFS", FileModeFS(fsys, "a.txt", 0o644, "msg"), FileModeFS(fsys, "a.txt", 0o600, "msg")},
+		{"FileSizeBetween", FileSizeBetween(file.Name(), 0, 0, "msg"), FileSizeBetween(file.Name(), 1, 2, "msg")},
+		{"FileSizeBetweenFS", FileSizeBetweenFS(fsys, "a.txt", 1, 1, "msg"), FileSizeBetweenFS(fsys, "a.txt", 2, 3, "msg")},
+		{"FileContains", FileContains(file.Name(), "", "msg"), FileContains(file.Name(), "a", "msg")},
+		{"FileContainsFS", FileContainsFS(fsys, "a.txt", "a", "msg"), FileContainsFS(fsys, "a.txt", "b", "msg")},
+		{"FileSHA256", FileSHA256(file.Name(), emptySHA256, "msg"), FileSHA256(file.Name(), "00", "msg")},
+		{"FileSHA256FS", FileSHA256FS(fsys, "a.txt", aSHA256, "msg"), FileSHA256FS(fsys, "a.txt", "00", "msg")},
+		{"Readable", Readable(file.Name(), "msg"), Readable(dir+"/missing", "msg")},
+		{"ReadableFS", ReadableFS(fsys, "a.txt", "msg"), ReadableFS(fsys, "missing", "msg")},
+		{"Writable", Writable(file.Name(), "msg"), Writable(dir+"/missing", "msg")},
+		{"TypeOf", TypeOf[string]("a", "msg"), TypeOf[string](1, "msg")},
+		{"TypeOfNot", TypeOfNot[string](1, "msg"), TypeOfNot[string]("a", "msg")},
+		{"Same", Same(&value, &value, "msg"), Same(&value, &other, "msg")},
+		{"NotSame", NotSame(&value, &other, "msg"), NotSame(&value, &value, "msg")},
+		{"PointeeEqual", PointeeEqual(&value, &value, "msg"), PointeeEqual(&value, &other, "msg")},
+		{"PointsToSame", PointsToSame(&value, &value, "msg"), PointsToSame(&value, &other, "msg")},
+		{"PointsToNotSame", PointsToNotSame(&value, &other, "msg"), PointsToNotSame(&value, nil, "msg")},
+		{"SliceHas", SliceHas([]int{1}, 1, "msg"), SliceHas([]int{1}, 2, "msg")},
+		{"SliceNotHas", SliceNotHas([]int{1}, 2, "msg"), SliceNotHas([]int{1}, 1, "msg")},
+		{"MapHas", MapHas(map[int]int{1: 1}, 1, "msg"), MapHas(map[int]int{}, 1, "msg")},
+		{"MapNotHas", MapNotHas(map[int]int{}, 1, "msg"), MapNotHas(map[int]int{1: 1}, 1, "msg")},
+		{"MapNotEmpty", MapNotEmpty(map[int]int{1: 1}, "msg"), MapNotEmpty(map[int]int{}, "msg")},
+		{"MapEmpty", MapEmpty(map[int]int{}, "msg"), MapEmpty(map[int]int{1: 1}, "msg")},
+		{"MapHasAll", MapHasAll(map[int]int{1: 1}, []int{1}, "msg"), MapHasAll(map[int]int{1: 1}, []int{1, 2}, "msg")},
+		{"MapValueEquals", MapValueEquals(map[int]int{1: 1}, 1, 1, "msg"), MapValueEquals(map[int]int{1: 1}, 1, 2, "msg")},
+		{"MapEqual", MapEqual(map[int]int{1: 1}, map[int]int{1: 1}, "msg"), MapEqual(map[int]int{1: 1}, map[int]int{1: 2}, "msg")},
+		{"MapKeysExactly", MapKeysExactly(map[int]int{1: 1}, []int{1}, "msg"), MapKeysExactly(map[int]int{1: 1}, []int{2}, "msg")},
+		{"MapEqual", MapEqual(map[any]int{nil: 1}, map[any]int{nil: 1}, "msg"), MapEqual(map[any]int{nil: 1}, map[any]int{nil: 2}, "msg")},
+		{"MapKeysExactly", MapKeysExactly(map[any]int{nil: 1}, []any{nil}, "msg"), MapKeysExactly(map[any]int{nil: 1}, []any{1}, "msg")},
+		{"MapLen", MapLen(map[int]int{1: 1}, 1, "msg"), MapLen(map[int]int{}, 1, "msg")},
+		{"IsEmpty", IsEmpty([]int{}, "msg"), IsEmpty([]int{1}, "msg")},
+		{"EqualText", EqualText("a\n", "a\n", "msg"), EqualText("a\n", "b\n", "msg")},
+		{"EqualBytes", EqualBytes([]byte{1}, []byte{1}, "msg"), EqualBytes([]byte{1}, []byte{2}, "msg")},
+		{"Panics", Panics(func() { panic("boom") }, "msg"), Panics(func() {}, "msg")},
+		{"NotPanics", NotPanics(func() {}, "msg"), NotPanics(func() { panic("boom") }, "msg")},
+		{"PanicsWithValue", PanicsWithValue(1, func() { panic(1) }, "msg"), PanicsWithValue(1, func() { panic(2) }, "msg")},
+		{"PanicsWithError", PanicsWithError("boom", func() { panic(errors.New("boom")) }, "msg"), PanicsWithError("boom", func() {}, "msg")},
+		{"ElementsMatch", ElementsMatch([]int{1, 2}, []int{2, 1}, "msg"), ElementsMatch([]int{1, 1}, []int{1}, "msg")},
+		{"ElementsMatchFunc", ElementsMatchFunc([]int{1}, []int{-1}, absEqual, "msg"), ElementsMatchFunc([]int{1}, []int{2}, absEqual, "msg")},
+		{"SubsetOf", SubsetOf([]int{1}, []int{1, 2}, "msg"), SubsetOf([]int{3}, []int{1, 2}, "msg")},
+		{"SubsetOfFunc", SubsetOfFunc([]int{-1}, []int{1, 2}, absEqual, "msg"), SubsetOfFunc([]int{3}, []int{1}, absEqual, "msg")},
+		{"SupersetOf", SupersetOf([]int{1, 2}, []int{1}, "msg"), SupersetOf([]int{1, 2}, []int{3}, "msg")},
+		{"SupersetOfFunc", SupersetOfFunc([]int{1, 2}, []int{-1}, absEqual, "msg"), SupersetOfFunc([]int{1}, []int{3}, absEqual, "msg")},
+		{"Disjoint", Disjoint([]int{1}, []int{2}, "msg"), Disjoint([]int{1}, []int{1}, "msg")},
+		{"DisjointFunc", DisjointFunc([]int{1}, []int{2}, absEqual, "msg"), DisjointFunc([]int{1}, []int{-1}, absEqual, "msg")},
+		{"SetEqual", SetEqual([]int{1, 1}, []int{1}, "msg"), SetEqual([]int{1}, []int{2}, "msg")},
+		{"SetEqualFunc", SetEqualFunc([]int{1}, []int{-1}, absEqual, "msg"), SetEqualFunc([]int{1}, []int{2}, absEqual, "msg")},
+		{"Unique", Unique([]int{1, 2}, "msg"), Unique([]int{1, 1}, "msg")},
+		{"UniqueFunc", UniqueFunc([]int{1, 2}, absEqual, "msg"), UniqueFunc([]int{1, -1}, absEqual, "msg")},
+		{"All", All([]int{1, 2}, positive, "msg"), All([]int{1, -2}, positive, "msg", must.ReportAll())},
+		{"Any", Any([]int{-1, 2}, positive, "msg"), Any([]int{-1}, positive, "msg")},
+		{"None", None([]int{-1}, positive, "msg"), None([]int{-1, 2}, positive, "msg", must.Describe("positive"))},
+		{"MapAll", MapAll(map[int]int{1: 1}, positiveEntry, "msg"), MapAll(map[int]int{1: -1}, positiveEntry, "msg")},
+		{"MapAny", MapAny(map[int]int{1: 1}, positiveEntry, "msg"), MapAny(map[int]int{}, positiveEntry, "msg")},
+		{"MapNone", MapNone(map[int]int{1: -1}, positiveEntry, "msg"), MapNone(map[int]int{1: 1}, positiveEntry, "msg")},
+		{"WithinDuration", WithinDuration(now, now.Add(time.Second), time.Second, "msg"), WithinDuration(now, now.Add(time.Hour), time.Second, "msg")},
+		{"Before", Before(now, later, "msg"), Before(later, now, "msg")},
+		{"After", After(later, now, "msg"), After(now, later, "msg")},
+		{"NotZeroTime", NotZeroTime(now, "msg"), NotZeroTime(time.Time{}, "msg")},
+		{"InLocation", InLocation(now.UTC(), time.UTC, "msg"), InLocation(now.UTC(), time.FixedZone("X", 3600), "msg")},
+		{"DurationBetween", DurationBetween(time.Second, 0, time.Minute, "msg"), DurationBetween(time.Hour, 0, time.Minute, "msg")},
+		{"Monotonic", Monotonic([]time.Time{now, later}, "msg"), Monotonic([]time.Time{later, now}, "msg")},
+		{"NotInFuture", NotInFuture(now.Add(-time.Hour), "msg"), NotInFuture(now.Add(time.Hour), "msg")},
+		{"NotExpired", NotExpired(now.Add(time.Hour), "msg"), NotExpired(now.Add(-time.Hour), "msg")},
+		{"DeepEqual", DeepEqual([]int{1}, []int{1}, "msg"), DeepEqual([]int{1}, []int{2}, "msg")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, tt.pass)
+			require.Error(t, tt.fail)
+
+			f, ok := must.AsFailure(tt.fail)
+			require.True(t, ok)
+			assert.Equal(t, tt.name, f.Assertion)
+			assert.Equal(t, "msg", f.Message)
+		})
+	}
+}
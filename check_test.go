@@ -0,0 +1,86 @@
+package must
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCheck tests recording failures as errors
+func TestCheck(t *testing.T) {
+
+	ResetFailureHandlers()
+	defer ResetFailureHandlers()
+
+	var handled bool
+	RegisterHandler(func(*Failure) { handled = true })
+
+	// Success case
+	assert.NoError(t, Check(func() { True(true, "passes") }))
+
+	// Failure case - only the first failure is returned
+	var reached bool
+	err := Check(func() {
+		Equal(1, 2, "first")
+		Equal(3, 4, "second")
+		reached = true
+	})
+	require.Error(t, err)
+	assert.True(t, reached, "Expected failed assertions to return inside Check")
+	assert.False(t, handled, "Expected handlers not to be called inside Check")
+
+	f, ok := AsFailure(err)
+	require.True(t, ok)
+	assert.Equal(t, "Equal", f.Assertion)
+	assert.Equal(t, "first", f.Message)
+	assert.Equal(t, 1, f.Expected)
+	assert.Contains(t, f.File, "check_test.go")
+
+	// The scope is removed afterwards
+	assert.Panics(t, func() { True(false, "should panic") })
+	assert.True(t, handled)
+}
+
+// TestCheckNested tests nested Check calls and scopes installed inside and around Check
+func TestCheckNested(t *testing.T) {
+
+	// Each Check returns the failures made directly inside it
+	var inner error
+	outer := Check(func() {
+		inner = Check(func() { Equal(1, 2, "inner") })
+		NotNil(inner, "should not fail")
+		Equal(3, 4, "outer")
+	})
+	require.Error(t, inner)
+	require.Error(t, outer)
+	assert.Equal(t, "inner", inner.(*Failure).Message)
+	assert.Equal(t, "outer", outer.(*Failure).Message)
+
+	// A policy installed inside Check takes precedence, one installed around it does not
+	captureLog(t)
+	assert.NoError(t, Check(func() {
+		WithPolicy(PolicyLog, func() { True(false, "logged") })
+	}))
+	WithPolicy(PolicyLog, func() {
+		assert.Error(t, Check(func() { True(false, "checked") }))
+	})
+
+	// Failures of a Check that panicked are not returned by later ones
+	assert.Panics(t, func() {
+		Check(func() {
+			True(false, "before panic")
+			panic("boom")
+		})
+	})
+	assert.NoError(t, Check(func() {}))
+	assert.Empty(t, checked)
+}
+
+// TestCheckPassing tests that a passing Check does not allocate
+func TestCheckPassing(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		Check(func() { Equal(1, 1, "passes") })
+	})
+	assert.Zero(t, allocs)
+}
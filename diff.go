@@ -0,0 +1,407 @@
+package must
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"unsafe"
+)
+
+// DiffOptions controls how differences between values are computed and reported.
+// Start from DefaultDiffOptions when changing a single option, the zero value disables all limits.
+type DiffOptions struct {
+	// Unexported makes DeepEqual compare unexported struct fields. They are ignored by default,
+	// except in structs without exported fields, such as the errors created by errors.New.
+	Unexported bool
+	// MaxDifferences limits the number of differences listed in the failure details, 0 means no limit.
+	MaxDifferences int
+
+	// Context is the number of unchanged lines shown around each change in text diffs.
+	Context int
+	// MaxLines limits the number of lines of a text diff, 0 means no limit.
+	MaxLines int
+	// MaxLineLength truncates longer lines of a text diff to this many characters, 0 means no limit.
+	MaxLineLength int
+}
+
+// DefaultDiffOptions are the options in effect unless changed with SetDiffOptions.
+var DefaultDiffOptions = DiffOptions{
+	MaxDifferences: 10,
+	Context:        3,
+	MaxLines:       200,
+	MaxLineLength:  200,
+}
+
+var diffOptions atomic.Pointer[DiffOptions]
+
+func init() {
+	SetDiffOptions(DefaultDiffOptions)
+}
+
+// SetDiffOptions sets the process-wide diff options and returns the previous ones.
+func SetDiffOptions(opts DiffOptions) DiffOptions {
+	if prev := diffOptions.Swap(&opts); prev != nil {
+		return *prev
+	}
+	return DefaultDiffOptions
+}
+
+// currentDiffOptions returns the diff options in effect.
+func currentDiffOptions() DiffOptions {
+	return *diffOptions.Load()
+}
+
+// DeepEqual checks if the given value is deeply equal to the expected value and panics if it is not.
+// Unlike Equal it works with values of any type, including slices, maps and structs containing them.
+// Values are walked recursively: pointers are followed (cycles are detected), NaN is equal to NaN,
+// and types with an Equal(T) bool method such as time.Time are compared with it.
+// Unexported struct fields are only compared when enabled with SetDiffOptions,
+// or when a struct has no exported fields at all.
+// The failure details list the path of every difference, e.g. `.Users[3].Email: "a@x" != "b@x"`.
+func DeepEqual(expected, value any, message string) {
+	if diffs, total := deepDiff(expected, value, currentDiffOptions()); total > 0 {
+		testHelper().Helper()
+		abortValues(message, formatDifferences("expected values to be deeply equal", diffs, total), expected, value)
+	}
+}
+
+// formatDifferences renders a list of differences under a headline.
+func formatDifferences(headline string, diffs []string, total int) string {
+	return formatList(headline, "difference", "differences", diffs, total)
+}
+
+// formatList renders a list of items under a headline, with the total number of items named by singular or plural.
+// Items beyond the listed ones are summarized.
+func formatList(headline, singular, plural string, items []string, total int) string {
+	var b strings.Builder
+	if total == 1 {
+		fmt.Fprintf(&b, "%s, found 1 %s:", headline, singular)
+	} else {
+		fmt.Fprintf(&b, "%s, found %d %s:", headline, total, plural)
+	}
+	for _, item := range items {
+		b.WriteString("\n\t")
+		b.WriteString(item)
+	}
+	if total > len(items) {
+		fmt.Fprintf(&b, "\n\t... and %d more", total-len(items))
+	}
+	return b.String()
+}
+
+// deepDiff compares a and b and returns the reported differences and their total count.
+func deepDiff(a, b any, opts DiffOptions) (diffs []string, total int) {
+	d := &differ{opts: opts, visited: map[visit]bool{}}
+	d.diff("", addressable(reflect.ValueOf(a)), addressable(reflect.ValueOf(b)))
+	return d.diffs, d.total
+}
+
+// visit identifies a pair of references that is being compared, to detect cycles.
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+type differ struct {
+	opts    DiffOptions
+	visited map[visit]bool
+	diffs   []string
+	total   int
+}
+
+// report records a difference at path.
+func (d *differ) report(path string, format string, args ...any) {
+	d.total++
+	if d.opts.MaxDifferences > 0 && len(d.diffs) >= d.opts.MaxDifferences {
+		return
+	}
+	if path == "" {
+		d.diffs = append(d.diffs, fmt.Sprintf(format, args...))
+	} else {
+		d.diffs = append(d.diffs, path+": "+fmt.Sprintf(format, args...))
+	}
+}
+
+// reportValues records that a and b differ at path.
+func (d *differ) reportValues(path string, a, b reflect.Value) {
+	d.report(path, "%s != %s", formatReflectValue(a), formatReflectValue(b))
+}
+
+func (d *differ) diff(path string, a, b reflect.Value) {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.reportValues(path, a, b)
+		}
+		return
+	}
+	if a.Type() != b.Type() {
+		d.report(path, "type %v != type %v", a.Type(), b.Type())
+		return
+	}
+
+	// Values read from unexported fields are exposed, so the values nested in their interfaces
+	// and maps can be copied and compared with their Equal method
+	a, _ = exposed(a)
+	b, _ = exposed(b)
+
+	if equal, ok := callEqualMethod(a, b); ok {
+		if !equal {
+			d.reportValues(path, a, b)
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.reportValues(path, a, b)
+			}
+			return
+		}
+		// Identical references are equal, already visited pairs are assumed equal to break cycles
+		if a.Pointer() == b.Pointer() && (a.Kind() != reflect.Slice || a.Len() == b.Len()) {
+			return
+		}
+		v := visit{a.Pointer(), b.Pointer(), a.Type()}
+		if d.visited[v] {
+			return
+		}
+		d.visited[v] = true
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		d.diff(path, a.Elem(), b.Elem())
+
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.reportValues(path, a, b)
+			}
+			return
+		}
+		d.diff(path, addressable(a.Elem()), addressable(b.Elem()))
+
+	case reflect.Struct:
+		// Opaque types such as the errors created by errors.New would always be equal
+		// without their unexported fields, so those are compared regardless of the options
+		if !d.opts.Unexported && !hasExportedFields(a.Type()) {
+			d.opts.Unexported = true
+			defer func() { d.opts.Unexported = false }()
+		}
+		for i := range a.NumField() {
+			field := a.Type().Field(i)
+			if !field.IsExported() && !d.opts.Unexported {
+				continue
+			}
+			d.diff(path+"."+field.Name, a.Field(i), b.Field(i))
+		}
+
+	case reflect.Slice, reflect.Array:
+		n := max(a.Len(), b.Len())
+		for i := range n {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= a.Len():
+				d.report(elemPath, "<missing> != %s", formatReflectValue(b.Index(i)))
+			case i >= b.Len():
+				d.report(elemPath, "%s != <missing>", formatReflectValue(a.Index(i)))
+			default:
+				d.diff(elemPath, a.Index(i), b.Index(i))
+			}
+		}
+
+	case reflect.Map:
+		for _, e := range mapPairs(a, b) {
+			keyPath := fmt.Sprintf("%s[%s]", path, formatReflectValue(e.key))
+			switch {
+			case !e.a.IsValid():
+				d.report(keyPath, "<missing> != %s", formatReflectValue(e.b))
+			case !e.b.IsValid():
+				d.report(keyPath, "%s != <missing>", formatReflectValue(e.a))
+			default:
+				d.diff(keyPath, addressable(e.a), addressable(e.b))
+			}
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if fa, fb := a.Float(), b.Float(); fa != fb && !(math.IsNaN(fa) && math.IsNaN(fb)) {
+			d.reportValues(path, a, b)
+		}
+
+	case reflect.Complex64, reflect.Complex128:
+		ca, cb := a.Complex(), b.Complex()
+		re := real(ca) == real(cb) || (math.IsNaN(real(ca)) && math.IsNaN(real(cb)))
+		im := imag(ca) == imag(cb) || (math.IsNaN(imag(ca)) && math.IsNaN(imag(cb)))
+		if !re || !im {
+			d.reportValues(path, a, b)
+		}
+
+	case reflect.Func:
+		if !a.IsNil() || !b.IsNil() {
+			d.report(path, "func values can only be compared to nil")
+		}
+
+	case reflect.Chan, reflect.UnsafePointer:
+		if a.Pointer() != b.Pointer() {
+			d.reportValues(path, a, b)
+		}
+
+	default:
+		if !a.Equal(b) {
+			d.reportValues(path, a, b)
+		}
+	}
+}
+
+// hasExportedFields reports whether the struct type t has at least one exported field.
+func hasExportedFields(t reflect.Type) bool {
+	for i := range t.NumField() {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// mapPair is a key of either of two compared maps with its value in each, invalid when the map lacks the key.
+type mapPair struct {
+	key, a, b reflect.Value
+}
+
+// mapPairs returns the union of the keys of two maps of the same type with their values, ordered by their formatted form.
+// Keys are looked up rather than compared by their formatted form, so keys of different types that
+// print the same, such as 1 and int64(1) in a map[any]V, are kept apart. NaN keys never match a lookup,
+// the NaN keys of both maps are paired in the order of their formatted values instead.
+func mapPairs(a, b reflect.Value) []mapPair {
+	var pairs, nanA, nanB []mapPair
+	for it := a.MapRange(); it.Next(); {
+		if key := it.Key(); isNaN(key) {
+			nanA = append(nanA, mapPair{key: key, a: it.Value()})
+		} else {
+			pairs = append(pairs, mapPair{key, it.Value(), b.MapIndex(key)})
+		}
+	}
+	for it := b.MapRange(); it.Next(); {
+		switch key := it.Key(); {
+		case isNaN(key):
+			nanB = append(nanB, mapPair{key: key, b: it.Value()})
+		case !a.MapIndex(key).IsValid():
+			pairs = append(pairs, mapPair{key: key, b: it.Value()})
+		}
+	}
+
+	sort.SliceStable(nanA, func(i, j int) bool {
+		return formatReflectValue(nanA[i].a) < formatReflectValue(nanA[j].a)
+	})
+	sort.SliceStable(nanB, func(i, j int) bool {
+		return formatReflectValue(nanB[i].b) < formatReflectValue(nanB[j].b)
+	})
+	for i := range max(len(nanA), len(nanB)) {
+		switch {
+		case i >= len(nanA):
+			pairs = append(pairs, nanB[i])
+		case i >= len(nanB):
+			pairs = append(pairs, nanA[i])
+		default:
+			pairs = append(pairs, mapPair{nanA[i].key, nanA[i].a, nanB[i].b})
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return formatKey(pairs[i].key) < formatKey(pairs[j].key)
+	})
+	return pairs
+}
+
+// isNaN reports whether v is a floating-point or complex NaN, possibly in an interface.
+func isNaN(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface:
+		return !v.IsNil() && isNaN(v.Elem())
+	case reflect.Float32, reflect.Float64:
+		return math.IsNaN(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return math.IsNaN(real(c)) || math.IsNaN(imag(c))
+	default:
+		return false
+	}
+}
+
+// formatKey formats a map key for sorting, including its dynamic type to order keys that print the same.
+func formatKey(key reflect.Value) string {
+	if key.Kind() == reflect.Interface && !key.IsNil() {
+		key = key.Elem()
+	}
+	if !key.IsValid() {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%v\x00%v", key, key.Type())
+}
+
+// callEqualMethod compares a and b with their Equal(T) bool method, if the type has one.
+func callEqualMethod(a, b reflect.Value) (equal bool, ok bool) {
+	a, okA := exposed(a)
+	b, okB := exposed(b)
+	if !okA || !okB || a.Kind() == reflect.Interface {
+		return false, false
+	}
+	method := a.MethodByName("Equal")
+	if !method.IsValid() {
+		return false, false
+	}
+	mt := method.Type()
+	if mt.NumIn() != 1 || mt.In(0) != a.Type() || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	if a.Kind() == reflect.Pointer && (a.IsNil() || b.IsNil()) {
+		return false, false
+	}
+	return method.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+// exposed returns a copy of v that can be used with Interface and Call,
+// giving access to values read from unexported fields when they are addressable.
+func exposed(v reflect.Value) (reflect.Value, bool) {
+	if v.CanInterface() {
+		return v, true
+	}
+	if v.CanAddr() {
+		return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem(), true // #nosec G103
+	}
+	return v, false
+}
+
+// addressable returns an addressable copy of v, so that values nested in it can be exposed.
+func addressable(v reflect.Value) reflect.Value {
+	if !v.IsValid() || v.CanAddr() || !v.CanInterface() {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+// formatReflectValue formats a value for failure details, quoting strings.
+func formatReflectValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%q", v)
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return fmt.Sprintf("%v(nil)", v.Type())
+		}
+		if v.Kind() == reflect.Interface {
+			return formatReflectValue(v.Elem())
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,167 @@
+package must
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type diffUser struct {
+	Name  string
+	Email string
+	Tags  []string
+	Meta  map[string]any
+	Next  *diffUser
+	Seen  time.Time
+	token string
+}
+
+type diffTeam struct {
+	Users []diffUser
+}
+
+// TestDeepEqual tests the DeepEqual function
+func TestDeepEqual(t *testing.T) {
+
+	now := time.Now()
+	team := func() diffTeam {
+		return diffTeam{Users: []diffUser{
+			{Name: "a", Email: "a@x", Tags: []string{"admin"}, Meta: map[string]any{"age": 30}, Seen: now},
+			{Name: "b", Email: "b@x", Seen: now},
+		}}
+	}
+
+	// Success cases
+	DeepEqual(team(), team(), "should not panic")
+	DeepEqual([]int{1, 2}, []int{1, 2}, "should not panic")
+	DeepEqual(map[string][]int{"a": {1}}, map[string][]int{"a": {1}}, "should not panic")
+	DeepEqual(math.NaN(), math.NaN(), "NaN equals NaN")
+	DeepEqual([]float64{math.NaN()}, []float64{math.NaN()}, "NaN equals NaN")
+	DeepEqual(nil, nil, "should not panic")
+
+	// time.Time is compared with its Equal method, ignoring location and monotonic reading
+	DeepEqual(now, now.UTC().Round(0), "same instant")
+
+	// Unexported fields are ignored by default
+	DeepEqual(diffUser{token: "a"}, diffUser{token: "b"}, "should not panic")
+
+	// Failure cases
+	t.Run("nested field", func(t *testing.T) {
+		expected, actual := team(), team()
+		actual.Users[1].Email = "c@x"
+
+		f := recoverFailure(t, func() { DeepEqual(expected, actual, "teams differ") })
+		assert.Equal(t, "DeepEqual", f.Assertion)
+		assert.Equal(t, "expected values to be deeply equal, found 1 difference:\n\t.Users[1].Email: \"b@x\" != \"c@x\"", f.Details)
+		assert.Equal(t, expected, f.Expected)
+	})
+
+	t.Run("slices and maps", func(t *testing.T) {
+		expected, actual := team(), team()
+		actual.Users[0].Tags = append(actual.Users[0].Tags, "owner")
+		actual.Users[0].Meta["age"] = 31
+		actual.Users[0].Meta["role"] = "dev"
+		actual.Users = append(actual.Users, diffUser{Name: "c"})
+
+		f := recoverFailure(t, func() { DeepEqual(expected, actual, "teams differ") })
+		assert.Contains(t, f.Details, "found 4 differences")
+		assert.Contains(t, f.Details, `.Users[0].Tags[1]: <missing> != "owner"`)
+		assert.Contains(t, f.Details, `.Users[0].Meta["age"]: 30 != 31`)
+		assert.Contains(t, f.Details, `.Users[0].Meta["role"]: <missing> != "dev"`)
+		assert.Contains(t, f.Details, `.Users[2]: <missing> != `)
+	})
+
+	t.Run("top level", func(t *testing.T) {
+		f := recoverFailure(t, func() { DeepEqual(1, 2, "numbers") })
+		assert.Contains(t, f.Details, "\n\t1 != 2")
+
+		f = recoverFailure(t, func() { DeepEqual(1, "1", "types") })
+		assert.Contains(t, f.Details, "type int != type string")
+
+		f = recoverFailure(t, func() { DeepEqual([]int(nil), []int{}, "nil and empty") })
+		assert.Contains(t, f.Details, "[]int(nil) != []")
+
+		recoverFailure(t, func() { DeepEqual(math.NaN(), 1.0, "NaN") })
+		recoverFailure(t, func() { DeepEqual(now, now.Add(time.Second), "times") })
+	})
+
+	t.Run("cycles", func(t *testing.T) {
+		a := &diffUser{Name: "a"}
+		a.Next = a
+		b := &diffUser{Name: "a"}
+		b.Next = b
+		DeepEqual(a, b, "should not panic")
+
+		b.Next = &diffUser{Name: "b"}
+		f := recoverFailure(t, func() { DeepEqual(a, b, "cycles differ") })
+		assert.Contains(t, f.Details, `.Next.Name: "a" != "b"`)
+	})
+
+	t.Run("unexported fields", func(t *testing.T) {
+		prev := SetDiffOptions(DiffOptions{Unexported: true})
+		defer SetDiffOptions(prev)
+
+		DeepEqual(diffUser{token: "a", Seen: now}, diffUser{token: "a", Seen: now.UTC()}, "should not panic")
+		f := recoverFailure(t, func() {
+			DeepEqual(diffUser{token: "a"}, diffUser{token: "b"}, "tokens differ")
+		})
+		assert.Contains(t, f.Details, `.token: "a" != "b"`)
+	})
+
+	t.Run("opaque types", func(t *testing.T) {
+		DeepEqual(errors.New("a"), errors.New("a"), "should not panic")
+		f := recoverFailure(t, func() { DeepEqual(errors.New("a"), errors.New("b"), "errors differ") })
+		assert.Contains(t, f.Details, `.s: "a" != "b"`)
+	})
+
+	t.Run("keys that print the same", func(t *testing.T) {
+		DeepEqual(map[any]int{1: 1, int64(1): 2, nil: 3}, map[any]int{1: 1, int64(1): 2, nil: 3}, "should not panic")
+
+		f := recoverFailure(t, func() {
+			DeepEqual(map[any]int{1: 1, int64(1): 2}, map[any]int{1: 1, int64(1): 3}, "maps differ")
+		})
+		assert.Contains(t, f.Details, "found 1 difference")
+		assert.Contains(t, f.Details, "[1]: 2 != 3")
+
+		f = recoverFailure(t, func() { DeepEqual(map[any]int{1: 1}, map[any]int{int64(1): 1}, "keys differ") })
+		assert.Contains(t, f.Details, "found 2 differences")
+	})
+
+	t.Run("NaN keys", func(t *testing.T) {
+		nan := math.NaN()
+		DeepEqual(map[float64]int{nan: 1, 1: 2}, map[float64]int{nan: 1, 1: 2}, "should not panic")
+		DeepEqual(map[any]int{nan: 1, nan: 2}, map[any]int{nan: 2, nan: 1}, "should not panic")
+
+		f := recoverFailure(t, func() { DeepEqual(map[float64]int{nan: 1}, map[float64]int{nan: 2}, "values differ") })
+		assert.Equal(t, "expected values to be deeply equal, found 1 difference:\n\t[NaN]: 1 != 2", f.Details)
+
+		f = recoverFailure(t, func() { DeepEqual(map[float64]int{nan: 1, nan: 2}, map[float64]int{nan: 1}, "missing key") })
+		assert.Equal(t, "expected values to be deeply equal, found 1 difference:\n\t[NaN]: 2 != <missing>", f.Details)
+	})
+
+	t.Run("Equal method behind unexported fields", func(t *testing.T) {
+		type wrapper struct {
+			v any
+			m map[string]time.Time
+		}
+		DeepEqual(wrapper{now, map[string]time.Time{"a": now}}, wrapper{now.Round(0), map[string]time.Time{"a": now.UTC()}}, "same instant")
+
+		f := recoverFailure(t, func() { DeepEqual(wrapper{v: now}, wrapper{v: now.Add(time.Second)}, "times differ") })
+		assert.Contains(t, f.Details, "found 1 difference:\n\t.v: ")
+	})
+
+	t.Run("difference limit", func(t *testing.T) {
+		prev := SetDiffOptions(DiffOptions{MaxDifferences: 2})
+		defer SetDiffOptions(prev)
+
+		f := recoverFailure(t, func() { DeepEqual([]int{1, 2, 3, 4}, []int{5, 6, 7, 8}, "all differ") })
+		require.Contains(t, f.Details, "found 4 differences")
+		assert.Contains(t, f.Details, "[1]: 2 != 6")
+		assert.NotContains(t, f.Details, "[2]")
+		assert.Contains(t, f.Details, "... and 2 more")
+	})
+}
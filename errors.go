@@ -0,0 +1,156 @@
+package must
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// maxErrorDepth bounds how deep error chains are printed, in case an error unwraps to itself.
+const maxErrorDepth = 32
+
+// ErrorIs checks if err matches target according to errors.Is and panics if it does not.
+// The failure details print the whole unwrap chain of err, including the branches of joined errors.
+func ErrorIs(err, target error, message string) {
+	if !errors.Is(err, target) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected error to match %s, got %s", describeError(target), formatErrorChain(err)), target, err)
+	}
+}
+
+// ErrorAs checks if an error in the chain of err is assignable to T according to errors.As and returns it,
+// and panics if there is none. The value is the zero T if the failure policy lets execution continue.
+//
+//	pathErr := must.ErrorAs[*fs.PathError](err, "expected a path error")
+func ErrorAs[T error](err error, message string) T {
+	var target T
+	if !errors.As(err, &target) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected error chain to contain a %v, got %s", reflect.TypeFor[T](), formatErrorChain(err)), nil, err)
+	}
+	return target
+}
+
+// ErrorContains checks if err is not nil and its message contains substring, and panics if not.
+func ErrorContains(err error, substring string, message string) {
+	if err == nil || !strings.Contains(err.Error(), substring) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected error message to contain %q, got %s", substring, formatErrorChain(err)), substring, err)
+	}
+}
+
+// ErrorMatches checks if err is not nil and its message matches the regular expression pattern, and panics if not.
+// Recently used patterns are kept compiled, an invalid pattern is reported as a failure.
+func ErrorMatches(err error, pattern string, message string) {
+	re, compileErr := compilePattern(pattern)
+	if compileErr != nil {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("invalid pattern %q: %v", pattern, compileErr))
+		return
+	}
+	if err == nil || !re.MatchString(err.Error()) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected error message to match %q, got %s", pattern, formatErrorChain(err)), pattern, err)
+	}
+}
+
+// maxPatterns is the number of compiled patterns kept in the cache.
+const maxPatterns = 256
+
+// patternCache is a least recently used cache of compiled regular expressions by their source.
+// It is bounded so patterns built at runtime do not grow it without limit.
+type patternCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   list.List // most recently used first, of *regexp.Regexp
+}
+
+var patterns = &patternCache{entries: map[string]*list.Element{}}
+
+// get returns the cached compilation of pattern.
+func (c *patternCache) get(pattern string) (*regexp.Regexp, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[pattern]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*regexp.Regexp), true
+}
+
+// put caches the compilation of a pattern, evicting the least recently used one when full.
+func (c *patternCache) put(re *regexp.Regexp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[re.String()]; ok {
+		return
+	}
+	c.entries[re.String()] = c.order.PushFront(re)
+	if c.order.Len() > maxPatterns {
+		oldest := c.order.Remove(c.order.Back()).(*regexp.Regexp)
+		delete(c.entries, oldest.String())
+	}
+}
+
+// compilePattern compiles a regular expression, reusing a recent compilation of the same pattern.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.get(pattern); ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns.put(re)
+	return re, nil
+}
+
+// describeError describes an error by its type and quoted message.
+func describeError(err error) string {
+	if err == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%T(%s)", err, quote(err.Error()))
+}
+
+// formatErrorChain renders the unwrap chain of err, one error per line.
+// The errors wrapped by a joined error are indented below it.
+func formatErrorChain(err error) string {
+	if err == nil {
+		return "nil"
+	}
+	var b strings.Builder
+	b.WriteString("error chain:")
+	writeErrorChain(&b, err, 1)
+	return b.String()
+}
+
+// writeErrorChain writes err and the errors it wraps at the given indentation depth.
+func writeErrorChain(b *strings.Builder, err error, depth int) {
+	for ; err != nil; depth++ {
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat("\t", depth))
+		if depth > maxErrorDepth {
+			b.WriteString("...")
+			return
+		}
+		b.WriteString(describeError(err))
+
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				writeErrorChain(b, inner, depth+1)
+			}
+			return
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package must
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errNotFound = errors.New("not found")
+
+// TestErrorIs tests the ErrorIs function
+func TestErrorIs(t *testing.T) {
+
+	// Success cases
+	ErrorIs(errNotFound, errNotFound, "should not panic")
+	ErrorIs(fmt.Errorf("load: %w", errNotFound), errNotFound, "should not panic")
+	ErrorIs(errors.Join(errors.New("other"), errNotFound), errNotFound, "should not panic")
+
+	// Failure cases
+	t.Run("different error", func(t *testing.T) {
+		err := fmt.Errorf("load config: %w", errors.New("permission denied"))
+		f := recoverFailure(t, func() { ErrorIs(err, errNotFound, "wrong error") })
+		assert.Equal(t, "ErrorIs", f.Assertion)
+		assert.Equal(t, `expected error to match *errors.errorString("not found"), got error chain:`+
+			"\n\t"+`*fmt.wrapError("load config: permission denied")`+
+			"\n\t\t"+`*errors.errorString("permission denied")`, f.Details)
+		assert.Equal(t, errNotFound, f.Expected)
+		assert.Equal(t, err, f.Actual)
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		f := recoverFailure(t, func() { ErrorIs(nil, errNotFound, "no error") })
+		assert.Equal(t, `expected error to match *errors.errorString("not found"), got nil`, f.Details)
+	})
+
+	t.Run("joined errors", func(t *testing.T) {
+		err := fmt.Errorf("save: %w", errors.Join(errors.New("a"), fmt.Errorf("b: %w", errors.New("c"))))
+		f := recoverFailure(t, func() { ErrorIs(err, errNotFound, "wrong error") })
+		assert.Contains(t, f.Details, "error chain:"+
+			"\n\t"+`*fmt.wrapError("save: a\nb: c")`+
+			"\n\t\t"+`*errors.joinError("a\nb: c")`+
+			"\n\t\t\t"+`*errors.errorString("a")`+
+			"\n\t\t\t"+`*fmt.wrapError("b: c")`+
+			"\n\t\t\t\t"+`*errors.errorString("c")`)
+	})
+}
+
+// TestErrorAs tests the ErrorAs function
+func TestErrorAs(t *testing.T) {
+
+	// Success case
+	pathErr := &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+	got := ErrorAs[*fs.PathError](fmt.Errorf("load: %w", pathErr), "should not panic")
+	assert.Same(t, pathErr, got)
+
+	// Failure case
+	f := recoverFailure(t, func() { ErrorAs[*fs.PathError](errNotFound, "not a path error") })
+	assert.Equal(t, "ErrorAs", f.Assertion)
+	assert.Equal(t, "expected error chain to contain a *fs.PathError, got error chain:\n\t"+`*errors.errorString("not found")`, f.Details)
+}
+
+// TestErrorContains tests the ErrorContains function
+func TestErrorContains(t *testing.T) {
+
+	// Success case
+	ErrorContains(fmt.Errorf("load: %w", errNotFound), "not found", "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { ErrorContains(errNotFound, "denied", "wrong message") })
+	assert.Equal(t, "ErrorContains", f.Assertion)
+	assert.Equal(t, `expected error message to contain "denied", got error chain:`+"\n\t"+`*errors.errorString("not found")`, f.Details)
+
+	f = recoverFailure(t, func() { ErrorContains(nil, "denied", "no error") })
+	assert.Equal(t, `expected error message to contain "denied", got nil`, f.Details)
+}
+
+// TestErrorMatches tests the ErrorMatches function
+func TestErrorMatches(t *testing.T) {
+
+	// Success case
+	ErrorMatches(fmt.Errorf("retry %d: %w", 3, errNotFound), `^retry \d+: not found$`, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { ErrorMatches(errNotFound, `^retry`, "wrong message") })
+	assert.Equal(t, "ErrorMatches", f.Assertion)
+	assert.Contains(t, f.Details, `expected error message to match "^retry", got error chain:`)
+
+	f = recoverFailure(t, func() { ErrorMatches(errNotFound, `(`, "invalid pattern") })
+	assert.Contains(t, f.Details, `invalid pattern "(": error parsing regexp`)
+}
+
+// selfError is an error that unwraps to itself.
+type selfError struct{}
+
+func (e *selfError) Error() string { return "self" }
+func (e *selfError) Unwrap() error { return e }
+
+// TestFormatErrorChain tests that chains that never end are cut off
+func TestFormatErrorChain(t *testing.T) {
+
+	chain := formatErrorChain(&selfError{})
+	assert.Contains(t, chain, `*must.selfError("self")`)
+	assert.Contains(t, chain, "...")
+}
@@ -0,0 +1,233 @@
+package must
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// modulePath is the import path of this module. Frames from packages under it
+// are treated as library internals when locating the caller of an assertion.
+const modulePath = "github.com/slayer/must"
+
+// Failure describes a failed assertion.
+// It is the value the program panics with and it implements error,
+// so a recovered value can be inspected with errors.As or AsFailure.
+type Failure struct {
+	Assertion string    // Name of the assertion that failed, e.g. "Equal".
+	Message   string    // Message passed to the assertion by the caller.
+	Details   string    // Description of what was expected and what was found.
+	Expected  any       // Expected value, if the assertion compares values.
+	Actual    any       // Actual value, if the assertion compares values.
+	File      string    // File of the call site that invoked the assertion.
+	Line      int       // Line of the call site that invoked the assertion.
+	Function  string    // Fully qualified name of the function that invoked the assertion.
+	Stack     string    // Stack trace starting at the call site, if enabled with SetCaptureStack.
+	Goroutine uint64    // ID of the goroutine the assertion failed on.
+	Time      time.Time // Time the failure was recorded.
+
+	// HandlerErrors holds the errors of failure handlers that panicked or timed out.
+	// It is set once all handlers have run, before the failure policy is applied.
+	HandlerErrors []error
+
+	scope *scope // Scope that sets the policy on the failing goroutine, nil for the global policy
+	check int    // Stack position of the Check that records the failure, 0 outside Check
+}
+
+// Error returns the failure message followed by its details and the call site.
+func (f *Failure) Error() string {
+	if f.File == "" {
+		return f.Message + ": " + f.Details
+	}
+	return fmt.Sprintf("%s: %s (at %s:%d)", f.Message, f.Details, f.File, f.Line)
+}
+
+// AsFailure reports whether v is, or wraps, a *Failure and returns it.
+// v is typically a value returned by recover() or an error.
+func AsFailure(v any) (*Failure, bool) {
+	err, ok := v.(error)
+	if !ok {
+		return nil, false
+	}
+	var f *Failure
+	if errors.As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
+
+var captureStack atomic.Bool
+
+// SetCaptureStack enables or disables capturing the full stack trace of failed assertions into Failure.Stack.
+// Capturing is disabled by default; the call site is always recorded.
+func SetCaptureStack(enabled bool) {
+	captureStack.Store(enabled)
+}
+
+// abort is a helper function that reports a failure with a message and details.
+// It is used internally by the assertion functions to handle assertion failures.
+// Depending on the failure policy abort may return, so callers must not rely on it to stop execution.
+func abort(message string, details string) {
+	f := newFailure(message, details)
+	f.testT().Helper()
+	fail(f)
+}
+
+// abortValues is like abort, but also records the expected and actual values on the failure.
+func abortValues(message string, details string, expected, actual any) {
+	f := newFailure(message, details)
+	f.testT().Helper()
+	f.Expected = expected
+	f.Actual = actual
+	fail(f)
+}
+
+// problem describes why a check shared by several assertions failed. Returning it instead of aborting
+// lets the assertions call testHelper only on their failure branch.
+type problem struct {
+	details          string
+	expected, actual any
+	values           bool // Whether expected and actual are recorded on the failure
+}
+
+// report reports the problem as a failure with the given message.
+func (p *problem) report(message string) {
+	testHelper().Helper()
+	if p.values {
+		abortValues(message, p.details, p.expected, p.actual)
+	} else {
+		abort(message, p.details)
+	}
+}
+
+// fail calls all registered handlers and then applies the failure policy, which panics with f by default.
+// Failures inside Check are only recorded.
+func fail(f *Failure) {
+	f.testT().Helper()
+	if f.check != 0 {
+		recordChecked(f, f.check)
+		return
+	}
+	runHandlers(f)
+	enforce(f)
+}
+
+// newFailure builds a Failure for the assertion that is currently failing.
+// It must be called directly from abort or one of its siblings.
+func newFailure(message string, details string) *Failure {
+	f := &Failure{
+		Message:   message,
+		Details:   details,
+		Goroutine: goroutineID(),
+		Time:      time.Now(),
+	}
+	f.scope = policyScope(f.Goroutine)
+	f.check = enclosingCheck(f.scope)
+
+	// Skip runtime.Callers, newFailure and abort
+	depth := 64
+	if captureStack.Load() {
+		depth = 1024
+	}
+	pcs := make([]uintptr, depth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	// The assertion is the outermost frame of the run of must frames that led to abort,
+	// the caller is the first frame outside the library.
+	var stack strings.Builder
+	inAssertion, inLibrary := true, true
+	for {
+		frame, more := frames.Next()
+		if inLibrary && !isLibraryFrame(frame) {
+			inLibrary = false
+			f.File, f.Line, f.Function = frame.File, frame.Line, frame.Function
+			if !captureStack.Load() {
+				break
+			}
+		}
+		if inLibrary && inAssertion {
+			if packageOf(frame.Function) == modulePath {
+				f.Assertion = shortFuncName(frame.Function)
+			} else {
+				inAssertion = false
+			}
+		}
+		if !inLibrary {
+			fmt.Fprintf(&stack, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	f.Stack = stack.String()
+	if f.Assertion == "" {
+		f.Assertion = "abort"
+	}
+	return f
+}
+
+// testT returns the test the failure is reported to, or a no-op when it is not reported to a test.
+// Functions on the failure path call f.testT().Helper() so the test output skips the library internals.
+func (f *Failure) testT() interface{ Helper() } {
+	if f.scope != nil && f.scope.t != nil {
+		return f.scope.t
+	}
+	return noHelper{}
+}
+
+type noHelper struct{}
+
+func (noHelper) Helper() {}
+
+// isLibraryFrame reports whether the frame belongs to this module's non-test code.
+func isLibraryFrame(frame runtime.Frame) bool {
+	if strings.HasSuffix(frame.File, "_test.go") {
+		return false
+	}
+	pkg := packageOf(frame.Function)
+	return pkg == modulePath || strings.HasPrefix(pkg, modulePath+"/")
+}
+
+// packageOf returns the import path of the package a function belongs to.
+// The function name is in the form reported by runtime.Frame.Function.
+func packageOf(function string) string {
+	// Type arguments may contain dots and slashes, drop them first
+	if i := strings.IndexByte(function, '['); i >= 0 {
+		function = function[:i]
+	}
+	slash := strings.LastIndexByte(function, '/')
+	if dot := strings.IndexByte(function[slash+1:], '.'); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
+
+// shortFuncName strips the package path, type arguments and closure suffixes from a function name.
+func shortFuncName(function string) string {
+	if i := strings.IndexByte(function, '['); i >= 0 {
+		function = function[:i]
+	}
+	function = strings.TrimPrefix(function, packageOf(function)+".")
+	if i := strings.IndexByte(function, '.'); i >= 0 {
+		function = function[:i]
+	}
+	return function
+}
+
+// goroutineID returns the ID of the current goroutine, parsed from its stack header.
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+
+	// The header has the form "goroutine 123 [running]:"
+	s := strings.TrimPrefix(string(buf[:n]), "goroutine ")
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		s = s[:i]
+	}
+	id, _ := strconv.ParseUint(s, 10, 64)
+	return id
+}
@@ -0,0 +1,108 @@
+package must
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recoverFailure runs fn and returns the *Failure it panicked with
+func recoverFailure(t *testing.T, fn func()) (f *Failure) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		require.NotNil(t, r, "Expected a panic")
+		var ok bool
+		f, ok = r.(*Failure)
+		require.True(t, ok, "Expected panic value to be *Failure, got %T", r)
+	}()
+	fn()
+	return nil
+}
+
+// TestFailureValue tests the structured value the assertions panic with
+func TestFailureValue(t *testing.T) {
+
+	f := recoverFailure(t, func() {
+		Equal(42, 43, "numbers differ")
+	})
+
+	assert.Equal(t, "Equal", f.Assertion)
+	assert.Equal(t, "numbers differ", f.Message)
+	assert.Equal(t, "expected 42 to be equal to 43", f.Details)
+	assert.Equal(t, 42, f.Expected)
+	assert.Equal(t, 43, f.Actual)
+	assert.Contains(t, f.File, "failure_test.go")
+	assert.NotZero(t, f.Line)
+	assert.NotZero(t, f.Goroutine)
+	assert.False(t, f.Time.IsZero())
+}
+
+// TestFailureCallSite tests the caller location recorded on failures
+func TestFailureCallSite(t *testing.T) {
+
+	f := recoverFailure(t, func() {
+		NoError(errors.New("boom"), "call failed")
+	})
+
+	assert.Contains(t, f.Function, "TestFailureCallSite")
+	assert.Contains(t, f.File, "failure_test.go")
+	assert.Equal(t, fmt.Sprintf("call failed: expected no error, got: boom (at %s:%d)", f.File, f.Line), f.Error())
+
+	// The stack trace is not captured by default
+	assert.Empty(t, f.Stack)
+
+	t.Run("with stack", func(t *testing.T) {
+		SetCaptureStack(true)
+		defer SetCaptureStack(false)
+
+		f := recoverFailure(t, func() {
+			Error(nil, "expected failure")
+		})
+
+		require.NotEmpty(t, f.Stack)
+		assert.Contains(t, f.Stack, "TestFailureCallSite")
+		assert.NotContains(t, f.Stack, "must.Error")
+		assert.Contains(t, f.Stack, fmt.Sprintf("%s:%d", f.File, f.Line))
+	})
+}
+
+// TestFailureAssertionName tests that the failing assertion is named correctly
+func TestFailureAssertionName(t *testing.T) {
+
+	f := recoverFailure(t, func() { NotNil(nil, "nil") })
+	assert.Equal(t, "NotNil", f.Assertion)
+
+	f = recoverFailure(t, func() { MapHas(map[string]int{}, "key", "missing") })
+	assert.Equal(t, "MapHas", f.Assertion)
+
+	f = recoverFailure(t, func() { abort("direct", "call") })
+	assert.Equal(t, "abort", f.Assertion)
+}
+
+// TestAsFailure tests the AsFailure helper and errors.As support
+func TestAsFailure(t *testing.T) {
+
+	f := recoverFailure(t, func() { True(false, "condition") })
+
+	// Wrapped failures are found with errors.As
+	err := fmt.Errorf("request failed: %w", f)
+	var target *Failure
+	require.True(t, errors.As(err, &target))
+	assert.Same(t, f, target)
+
+	got, ok := AsFailure(err)
+	assert.True(t, ok)
+	assert.Same(t, f, got)
+
+	// Foreign values are not failures
+	_, ok = AsFailure("plain panic")
+	assert.False(t, ok)
+	_, ok = AsFailure(errors.New("plain error"))
+	assert.False(t, ok)
+	_, ok = AsFailure(nil)
+	assert.False(t, ok)
+}
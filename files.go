@@ -0,0 +1,375 @@
+package must
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// The filesystem assertions come in two forms: the plain functions take a path on the OS file system,
+// the functions ending in FS take a name in an fs.FS such as an embed.FS, an os.DirFS or a fstest.MapFS.
+// Errors other than a missing file, e.g. permission denied, are reported as failures with the error.
+// Both forms share an unexported check that returns the problem found, or nil if the assertion holds.
+
+// FileExists checks if the given path exists and is not a directory, and panics if it is not.
+func FileExists(path string, message string) {
+	if p := fileExists(osFS{}, path); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// FileExistsFS is like FileExists for a file in fsys.
+func FileExistsFS(fsys fs.FS, name string, message string) {
+	if p := fileExists(fsys, name); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+func fileExists(fsys fs.FS, name string) *problem {
+	info, p := statFile(fsys, name)
+	if p == nil && info.IsDir() {
+		p = &problem{details: fmt.Sprintf("expected %s to be a file, but it is a directory", name)}
+	}
+	return p
+}
+
+// DirExists checks if the given path exists and is a directory, and panics if it is not.
+func DirExists(path string, message string) {
+	if p := dirExists(osFS{}, path); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// DirExistsFS is like DirExists for a directory in fsys.
+func DirExistsFS(fsys fs.FS, name string, message string) {
+	if p := dirExists(fsys, name); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+func dirExists(fsys fs.FS, name string) *problem {
+	info, p := statFile(fsys, name)
+	if p == nil && !info.IsDir() {
+		p = &problem{details: fmt.Sprintf("expected %s to be a directory, but it is not", name)}
+	}
+	return p
+}
+
+// NotExists checks if nothing exists at the given path and panics if something does.
+// A dangling symlink counts as existing.
+func NotExists(path string, message string) {
+	if p := notExists(osFS{}, path); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// NotExistsFS is like NotExists for a name in fsys.
+func NotExistsFS(fsys fs.FS, name string, message string) {
+	if p := notExists(fsys, name); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+func notExists(fsys fs.FS, name string) *problem {
+	_, err := lstat(fsys, name)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return &problem{details: fmt.Sprintf("expected %s to not exist, but it could not be checked: %v", name, err)}
+	default:
+		return &problem{details: fmt.Sprintf("expected %s to not exist, but it does", name)}
+	}
+}
+
+// IsRegularFile checks if the given path is a regular file, following symlinks, and panics if it is not.
+// Directories, devices, sockets and named pipes are not regular files.
+func IsRegularFile(path string, message string) {
+	if p := isRegularFile(osFS{}, path); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// IsRegularFileFS is like IsRegularFile for a file in fsys.
+func IsRegularFileFS(fsys fs.FS, name string, message string) {
+	if p := isRegularFile(fsys, name); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+func isRegularFile(fsys fs.FS, name string) *problem {
+	info, p := statFile(fsys, name)
+	if p == nil && !info.Mode().IsRegular() {
+		p = &problem{details: fmt.Sprintf("expected %s to be a regular file, got mode %v", name, info.Mode())}
+	}
+	return p
+}
+
+// IsSymlink checks if the given path is a symbolic link and panics if it is not.
+func IsSymlink(path string, message string) {
+	if p := isSymlink(osFS{}, path); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// IsSymlinkFS is like IsSymlink for a name in fsys.
+// File systems that cannot report symbolic links without following them always fail.
+func IsSymlinkFS(fsys fs.FS, name string, message string) {
+	if p := isSymlink(fsys, name); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+func isSymlink(fsys fs.FS, name string) *problem {
+	if _, ok := fsys.(lstatFS); !ok {
+		return &problem{details: fmt.Sprintf("expected %s to be a symlink, but the file system does not support symlinks", name)}
+	}
+	info, err := lstat(fsys, name)
+	if err != nil {
+		return statProblem(name, err)
+	}
+	if info.Mode()&fs.ModeSymlink == 0 {
+		return &problem{details: fmt.Sprintf("expected %s to be a symlink, got mode %v", name, info.Mode())}
+	}
+	return nil
+}
+
+// FileMode checks if the permission bits of the given path are exactly perm and panics if they are not.
+func FileMode(path string, perm fs.FileMode, message string) {
+	if p := fileMode(osFS{}, path, perm); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// FileModeFS is like FileMode for a file in fsys.
+func FileModeFS(fsys fs.FS, name string, perm fs.FileMode, message string) {
+	if p := fileMode(fsys, name, perm); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+func fileMode(fsys fs.FS, name string, perm fs.FileMode) *problem {
+	info, p := statFile(fsys, name)
+	if p == nil && info.Mode().Perm() != perm.Perm() {
+		p = &problem{
+			details:  fmt.Sprintf("expected %s to have permissions %v, got %v", name, perm.Perm(), info.Mode().Perm()),
+			expected: perm.Perm(),
+			actual:   info.Mode().Perm(),
+			values:   true,
+		}
+	}
+	return p
+}
+
+// FileSizeBetween checks if the size of the given file in bytes is within [low, high] and panics if it is not.
+func FileSizeBetween(path string, low, high int64, message string) {
+	if p := fileSizeBetween(osFS{}, path, low, high); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// FileSizeBetweenFS is like FileSizeBetween for a file in fsys.
+func FileSizeBetweenFS(fsys fs.FS, name string, low, high int64, message string) {
+	if p := fileSizeBetween(fsys, name, low, high); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+func fileSizeBetween(fsys fs.FS, name string, low, high int64) *problem {
+	info, p := statFile(fsys, name)
+	if p == nil && (info.Size() < low || info.Size() > high) {
+		p = &problem{
+			details:  fmt.Sprintf("expected size of %s to be between %d and %d bytes, got %d bytes", name, low, high, info.Size()),
+			expected: [2]int64{low, high},
+			actual:   info.Size(),
+			values:   true,
+		}
+	}
+	return p
+}
+
+// FileContains checks if the content of the given file contains substring and panics if it does not.
+func FileContains(path string, substring string, message string) {
+	if p := fileContains(osFS{}, path, substring); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// FileContainsFS is like FileContains for a file in fsys.
+func FileContainsFS(fsys fs.FS, name string, substring string, message string) {
+	if p := fileContains(fsys, name, substring); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+func fileContains(fsys fs.FS, name string, substring string) *problem {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return statProblem(name, err)
+	}
+	if !strings.Contains(string(content), substring) {
+		return &problem{details: fmt.Sprintf("expected %s to contain %s, but it does not", name, quote(substring))}
+	}
+	return nil
+}
+
+// FileSHA256 checks if the SHA-256 checksum of the given file is the hex-encoded sum and panics if it is not.
+func FileSHA256(path string, sum string, message string) {
+	if p := fileSHA256(osFS{}, path, sum); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// FileSHA256FS is like FileSHA256 for a file in fsys.
+func FileSHA256FS(fsys fs.FS, name string, sum string, message string) {
+	if p := fileSHA256(fsys, name, sum); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+func fileSHA256(fsys fs.FS, name string, sum string) *problem {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return statProblem(name, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return &problem{details: fmt.Sprintf("could not read %s: %v", name, err)}
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, sum) {
+		return &problem{
+			details:  fmt.Sprintf("expected SHA-256 of %s to be %s, got %s", name, sum, actual),
+			expected: sum,
+			actual:   actual,
+			values:   true,
+		}
+	}
+	return nil
+}
+
+// Readable checks if the given path can be opened for reading and panics if it cannot.
+func Readable(path string, message string) {
+	if p := readable(osFS{}, path); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// ReadableFS is like Readable for a name in fsys.
+func ReadableFS(fsys fs.FS, name string, message string) {
+	if p := readable(fsys, name); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+func readable(fsys fs.FS, name string) *problem {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return &problem{details: fmt.Sprintf("expected %s to be readable: %v", name, err)}
+	}
+	_ = file.Close()
+	return nil
+}
+
+// Writable checks if the given path can be written to and panics if it cannot.
+// A file is opened for writing without changing it, for a directory a temporary file is created and removed.
+// There is no FS variant, as fs.FS is read-only.
+func Writable(path string, message string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		testHelper().Helper()
+		statProblem(path, err).report(message)
+		return
+	}
+	if info.IsDir() {
+		file, err := os.CreateTemp(path, ".must-writable-*")
+		if err != nil {
+			testHelper().Helper()
+			abort(message, fmt.Sprintf("expected directory %s to be writable: %v", path, err))
+			return
+		}
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY, 0) // #nosec G304
+	if err != nil {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("expected %s to be writable: %v", path, err))
+		return
+	}
+	_ = file.Close()
+}
+
+// statFile returns the file info of name, following symlinks, or the problem if it cannot be read.
+func statFile(fsys fs.FS, name string) (fs.FileInfo, *problem) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, statProblem(name, err)
+	}
+	return info, nil
+}
+
+// statProblem describes that name does not exist or that its information could not be read.
+func statProblem(name string, err error) *problem {
+	if errors.Is(err, fs.ErrNotExist) {
+		return &problem{details: fmt.Sprintf("expected %s to exist, but it does not", name)}
+	}
+	return &problem{details: fmt.Sprintf("could not check %s: %v", name, err)}
+}
+
+// lstatFS is implemented by file systems that can report a symbolic link without following it.
+type lstatFS interface {
+	fs.FS
+	Lstat(name string) (fs.FileInfo, error)
+}
+
+// lstat returns the file info of name without following a final symbolic link, if fsys supports it.
+func lstat(fsys fs.FS, name string) (fs.FileInfo, error) {
+	if l, ok := fsys.(lstatFS); ok {
+		return l.Lstat(name)
+	}
+	return fs.Stat(fsys, name)
+}
+
+// osFS gives the path-based assertions an fs.FS view of the OS file system. Unlike os.DirFS
+// it has no root and takes OS paths as is, including absolute paths and paths with "..".
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name) // #nosec G304
+}
+
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (osFS) Lstat(name string) (fs.FileInfo, error) {
+	return os.Lstat(name)
+}
@@ -0,0 +1,194 @@
+package must
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// helloSHA256 is the SHA-256 checksum of "hello world"
+const helloSHA256 = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+// testFiles creates a directory with a file, a subdirectory and a symlink to the file
+func testFiles(t *testing.T) (dir, file, link string) {
+	dir = t.TempDir()
+	file = filepath.Join(dir, "file.txt")
+	link = filepath.Join(dir, "link")
+	require.NoError(t, os.WriteFile(file, []byte("hello world"), 0o640))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.Symlink(file, link))
+	return dir, file, link
+}
+
+// TestExistenceAssertions tests the FileExists, DirExists and NotExists functions
+func TestExistenceAssertions(t *testing.T) {
+
+	dir, file, link := testFiles(t)
+	missing := filepath.Join(dir, "missing")
+
+	// Success cases
+	FileExists(file, "should not panic")
+	FileExists(link, "should not panic")
+	DirExists(dir, "should not panic")
+	NotExists(missing, "should not panic")
+
+	// Failure cases
+	t.Run("FileExists", func(t *testing.T) {
+		f := recoverFailure(t, func() { FileExists(missing, "missing") })
+		assert.Equal(t, "FileExists", f.Assertion)
+		assert.Equal(t, "expected "+missing+" to exist, but it does not", f.Details)
+
+		f = recoverFailure(t, func() { FileExists(dir, "directory") })
+		assert.Equal(t, "expected "+dir+" to be a file, but it is a directory", f.Details)
+	})
+
+	t.Run("DirExists", func(t *testing.T) {
+		f := recoverFailure(t, func() { DirExists(file, "file") })
+		assert.Equal(t, "DirExists", f.Assertion)
+		assert.Equal(t, "expected "+file+" to be a directory, but it is not", f.Details)
+	})
+
+	t.Run("NotExists", func(t *testing.T) {
+		f := recoverFailure(t, func() { NotExists(file, "exists") })
+		assert.Equal(t, "NotExists", f.Assertion)
+		assert.Equal(t, "expected "+file+" to not exist, but it does", f.Details)
+
+		dangling := filepath.Join(dir, "dangling")
+		require.NoError(t, os.Symlink(missing, dangling))
+		recoverFailure(t, func() { NotExists(dangling, "dangling symlink") })
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("permissions are not enforced for root")
+		}
+		locked := filepath.Join(dir, "locked")
+		require.NoError(t, os.Mkdir(locked, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(locked, "file"), nil, 0o644))
+		require.NoError(t, os.Chmod(locked, 0))
+		t.Cleanup(func() { _ = os.Chmod(locked, 0o755) })
+
+		f := recoverFailure(t, func() { FileExists(filepath.Join(locked, "file"), "denied") })
+		assert.Contains(t, f.Details, "permission denied")
+		f = recoverFailure(t, func() { NotExists(filepath.Join(locked, "file"), "denied") })
+		assert.Contains(t, f.Details, "could not be checked")
+	})
+}
+
+// TestFileTypeAssertions tests the IsRegularFile, IsSymlink and FileMode functions
+func TestFileTypeAssertions(t *testing.T) {
+
+	dir, file, link := testFiles(t)
+
+	// Success cases
+	IsRegularFile(file, "should not panic")
+	IsRegularFile(link, "should not panic")
+	IsSymlink(link, "should not panic")
+	FileMode(file, 0o640, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { IsRegularFile(dir, "directory") })
+	assert.Equal(t, "IsRegularFile", f.Assertion)
+	assert.Contains(t, f.Details, "expected "+dir+" to be a regular file, got mode d")
+
+	f = recoverFailure(t, func() { IsSymlink(file, "not a link") })
+	assert.Equal(t, "expected "+file+" to be a symlink, got mode -rw-r-----", f.Details)
+
+	f = recoverFailure(t, func() { FileMode(file, 0o600, "wrong mode") })
+	assert.Equal(t, "FileMode", f.Assertion)
+	assert.Equal(t, "expected "+file+" to have permissions -rw-------, got -rw-r-----", f.Details)
+	assert.Equal(t, fs.FileMode(0o600), f.Expected)
+	assert.Equal(t, fs.FileMode(0o640), f.Actual)
+}
+
+// TestFileContentAssertions tests the FileSizeBetween, FileContains and FileSHA256 functions
+func TestFileContentAssertions(t *testing.T) {
+
+	_, file, _ := testFiles(t)
+
+	// Success cases
+	FileSizeBetween(file, 1, 11, "should not panic")
+	FileContains(file, "o w", "should not panic")
+	FileSHA256(file, helloSHA256, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { FileSizeBetween(file, 100, 200, "too small") })
+	assert.Equal(t, "FileSizeBetween", f.Assertion)
+	assert.Equal(t, "expected size of "+file+" to be between 100 and 200 bytes, got 11 bytes", f.Details)
+
+	f = recoverFailure(t, func() { FileContains(file, "bye", "missing text") })
+	assert.Equal(t, "expected "+file+` to contain "bye", but it does not`, f.Details)
+
+	f = recoverFailure(t, func() { FileSHA256(file, "00", "corrupted") })
+	assert.Equal(t, "expected SHA-256 of "+file+" to be 00, got "+helloSHA256, f.Details)
+}
+
+// TestAccessAssertions tests the Readable and Writable functions
+func TestAccessAssertions(t *testing.T) {
+
+	dir, file, _ := testFiles(t)
+
+	// Success cases
+	Readable(file, "should not panic")
+	Readable(dir, "should not panic")
+	Writable(file, "should not panic")
+	Writable(dir, "should not panic")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "Writable should not leave files behind")
+
+	// Failure cases
+	f := recoverFailure(t, func() { Readable(filepath.Join(dir, "missing"), "missing") })
+	assert.Equal(t, "Readable", f.Assertion)
+	assert.Contains(t, f.Details, "to be readable")
+
+	f = recoverFailure(t, func() { Writable(filepath.Join(dir, "missing"), "missing") })
+	assert.Contains(t, f.Details, "to exist, but it does not")
+
+	if os.Geteuid() != 0 {
+		require.NoError(t, os.Chmod(file, 0o440))
+		f = recoverFailure(t, func() { Writable(file, "read-only") })
+		assert.Contains(t, f.Details, "permission denied")
+	}
+}
+
+// TestFSAssertions tests the FS variants with an in-memory file system
+func TestFSAssertions(t *testing.T) {
+
+	fsys := fstest.MapFS{
+		"config/app.yaml": {Data: []byte("hello world"), Mode: 0o644},
+	}
+
+	// Success cases
+	FileExistsFS(fsys, "config/app.yaml", "should not panic")
+	DirExistsFS(fsys, "config", "should not panic")
+	NotExistsFS(fsys, "config/other.yaml", "should not panic")
+	IsRegularFileFS(fsys, "config/app.yaml", "should not panic")
+	FileModeFS(fsys, "config/app.yaml", 0o644, "should not panic")
+	FileSizeBetweenFS(fsys, "config/app.yaml", 11, 11, "should not panic")
+	FileContainsFS(fsys, "config/app.yaml", "hello", "should not panic")
+	FileSHA256FS(fsys, "config/app.yaml", helloSHA256, "should not panic")
+	ReadableFS(fsys, "config/app.yaml", "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { FileExistsFS(fsys, "config", "directory") })
+	assert.Equal(t, "FileExistsFS", f.Assertion)
+	assert.Equal(t, "expected config to be a file, but it is a directory", f.Details)
+
+	f = recoverFailure(t, func() { DirExistsFS(fsys, "missing", "missing") })
+	assert.Equal(t, "expected missing to exist, but it does not", f.Details)
+
+	// Hide the Lstat method that MapFS has in newer Go versions
+	noLstat := struct{ fs.FS }{fsys}
+	f = recoverFailure(t, func() { IsSymlinkFS(noLstat, "config/app.yaml", "no symlinks") })
+	assert.Equal(t, "expected config/app.yaml to be a symlink, but the file system does not support symlinks", f.Details)
+
+	f = recoverFailure(t, func() { FileContainsFS(fsys, "missing", "hello", "missing") })
+	assert.Equal(t, "expected missing to exist, but it does not", f.Details)
+}
@@ -0,0 +1,178 @@
+package must
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// InDelta checks if value is within delta of expected and panics if it is not.
+// NaN is never within delta of anything; equal infinities are.
+func InDelta[T Float](expected, value, delta T, message string) {
+	if ok, diff := inDelta(expected, value, delta); !ok {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be within %v of %v, difference is %v", value, delta, expected, diff), expected, value)
+	}
+}
+
+// InEpsilon checks if the relative error between value and expected, |expected-value|/|expected|,
+// is at most epsilon and panics if it is not. If expected is zero, value must be zero as well.
+func InEpsilon[T Float](expected, value, epsilon T, message string) {
+	if ok, relative := inEpsilon(expected, value, epsilon); !ok {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be within relative error %v of %v, relative error is %v", value, epsilon, expected, relative), expected, value)
+	}
+}
+
+// WithinULPs checks if value is at most ulps representable floating-point numbers away from expected
+// and panics if it is not. Positive and negative zero are zero ULPs apart, NaN is never within any distance.
+func WithinULPs[T Float](expected, value T, ulps uint64, message string) {
+	distance, ok := ulpDistance(expected, value)
+	if !ok {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be within %d ULPs of %v, got NaN", value, ulps, expected), expected, value)
+		return
+	}
+	if distance > ulps {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be within %d ULPs of %v, distance is %d ULPs", value, ulps, expected, distance), expected, value)
+	}
+}
+
+// NotNaN checks if the given value is not NaN and panics if it is.
+func NotNaN[T Float](value T, message string) {
+	if math.IsNaN(float64(value)) {
+		testHelper().Helper()
+		abort(message, "expected a number, got NaN")
+	}
+}
+
+// Finite checks if the given value is neither NaN nor an infinity and panics if it is.
+func Finite[T Float](value T, message string) {
+	if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("expected a finite number, got %v", value))
+	}
+}
+
+// InDeltaSlice checks if the slices have the same length and every element of value is within delta
+// of the corresponding element of expected, and panics if not. The details list the offending indices.
+func InDeltaSlice[T Float](expected, value []T, delta T, message string) {
+	if len(expected) != len(value) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected slice of length %d, got length %d", len(expected), len(value)), expected, value)
+		return
+	}
+	var diffs []string
+	total := 0
+	for i := range expected {
+		if ok, diff := inDelta(expected[i], value[i], delta); !ok {
+			diffs = appendLimited(diffs, fmt.Sprintf("[%d]: %v is not within %v of %v, difference is %v", i, value[i], delta, expected[i], diff))
+			total++
+		}
+	}
+	if total > 0 {
+		testHelper().Helper()
+		abortValues(message, formatDifferences(fmt.Sprintf("expected all elements to be within %v", delta), diffs, total), expected, value)
+	}
+}
+
+// InEpsilonSlice checks if the slices have the same length and every element of value is within relative error
+// epsilon of the corresponding element of expected, and panics if not. The details list the offending indices.
+func InEpsilonSlice[T Float](expected, value []T, epsilon T, message string) {
+	if len(expected) != len(value) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected slice of length %d, got length %d", len(expected), len(value)), expected, value)
+		return
+	}
+	var diffs []string
+	total := 0
+	for i := range expected {
+		if ok, relative := inEpsilon(expected[i], value[i], epsilon); !ok {
+			diffs = appendLimited(diffs, fmt.Sprintf("[%d]: %v is not within relative error %v of %v, relative error is %v", i, value[i], epsilon, expected[i], relative))
+			total++
+		}
+	}
+	if total > 0 {
+		testHelper().Helper()
+		abortValues(message, formatDifferences(fmt.Sprintf("expected all elements to be within relative error %v", epsilon), diffs, total), expected, value)
+	}
+}
+
+// appendLimited appends a difference unless the MaxDifferences diff option has been reached.
+func appendLimited(diffs []string, diff string) []string {
+	if limit := currentDiffOptions().MaxDifferences; limit > 0 && len(diffs) >= limit {
+		return diffs
+	}
+	return append(diffs, diff)
+}
+
+// inDelta reports whether a and b are within delta of each other and returns their difference.
+func inDelta[T Float](a, b, delta T) (bool, float64) {
+	fa, fb := float64(a), float64(b)
+	if math.IsNaN(fa) || math.IsNaN(fb) {
+		return false, math.NaN()
+	}
+	if fa == fb {
+		return true, 0
+	}
+	diff := math.Abs(fa - fb)
+	return diff <= float64(delta), diff
+}
+
+// inEpsilon reports whether the relative error of b with respect to a is within epsilon and returns it.
+func inEpsilon[T Float](a, b, epsilon T) (bool, float64) {
+	fa, fb := float64(a), float64(b)
+	if math.IsNaN(fa) || math.IsNaN(fb) {
+		return false, math.NaN()
+	}
+	if fa == fb {
+		return true, 0
+	}
+	if fa == 0 {
+		return false, math.Inf(1)
+	}
+	relative := math.Abs(fa-fb) / math.Abs(fa)
+	return relative <= float64(epsilon), relative
+}
+
+// ulpDistance returns the number of representable values between a and b in the precision of T.
+// It reports false if either value is NaN.
+func ulpDistance[T Float](a, b T) (uint64, bool) {
+	if math.IsNaN(float64(a)) || math.IsNaN(float64(b)) {
+		return 0, false
+	}
+	var ia, ib int64
+	if unsafe.Sizeof(a) == 4 {
+		ia, ib = int64(orderedBits32(float32(a))), int64(orderedBits32(float32(b)))
+	} else {
+		ia, ib = orderedBits64(float64(a)), orderedBits64(float64(b))
+	}
+	if ia > ib {
+		return uint64(ia) - uint64(ib), true
+	}
+	return uint64(ib) - uint64(ia), true
+}
+
+// orderedBits64 maps a float64 to an integer so that adjacent floats map to adjacent integers.
+func orderedBits64(f float64) int64 {
+	bits := int64(math.Float64bits(f))
+	if bits < 0 {
+		bits = math.MinInt64 - bits
+	}
+	return bits
+}
+
+// orderedBits32 maps a float32 to an integer so that adjacent floats map to adjacent integers.
+func orderedBits32(f float32) int32 {
+	bits := int32(math.Float32bits(f))
+	if bits < 0 {
+		bits = math.MinInt32 - bits
+	}
+	return bits
+}
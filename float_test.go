@@ -0,0 +1,116 @@
+package must
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInDelta tests the InDelta and InDeltaSlice functions
+func TestInDelta(t *testing.T) {
+
+	// Success cases
+	InDelta(1.0, 1.05, 0.1, "should not panic")
+	InDelta(float32(1), float32(0.95), 0.1, "should not panic")
+	InDelta(math.Inf(1), math.Inf(1), 0.1, "should not panic")
+	InDeltaSlice([]float64{1, 2}, []float64{1.01, 1.99}, 0.05, "should not panic")
+
+	// Failure cases
+	t.Run("outside delta", func(t *testing.T) {
+		f := recoverFailure(t, func() { InDelta(1.0, 1.5, 0.1, "prices differ") })
+		assert.Equal(t, "InDelta", f.Assertion)
+		assert.Equal(t, "expected 1.5 to be within 0.1 of 1, difference is 0.5", f.Details)
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		f := recoverFailure(t, func() { InDelta(1.0, math.NaN(), 0.1, "NaN") })
+		assert.Contains(t, f.Details, "difference is NaN")
+		recoverFailure(t, func() { InDelta(math.NaN(), math.NaN(), 0.1, "NaN") })
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		f := recoverFailure(t, func() {
+			InDeltaSlice([]float64{1, 2, 3}, []float64{1, 2.5, 4}, 0.1, "slices differ")
+		})
+		assert.Contains(t, f.Details, "found 2 differences")
+		assert.Contains(t, f.Details, "[1]: 2.5 is not within 0.1 of 2, difference is 0.5")
+		assert.Contains(t, f.Details, "[2]: 4 is not within 0.1 of 3, difference is 1")
+
+		f = recoverFailure(t, func() { InDeltaSlice([]float32{1}, []float32{1, 2}, 0.1, "lengths differ") })
+		assert.Equal(t, "expected slice of length 1, got length 2", f.Details)
+	})
+}
+
+// TestInEpsilon tests the InEpsilon and InEpsilonSlice functions
+func TestInEpsilon(t *testing.T) {
+
+	// Success cases
+	InEpsilon(100.0, 101.0, 0.01, "should not panic")
+	InEpsilon(-100.0, -99.5, 0.01, "should not panic")
+	InEpsilon(0.0, 0.0, 0.01, "should not panic")
+	InEpsilonSlice([]float32{100, 200}, []float32{100.5, 199}, 0.01, "should not panic")
+
+	// Failure cases
+	t.Run("outside epsilon", func(t *testing.T) {
+		f := recoverFailure(t, func() { InEpsilon(100.0, 110.0, 0.05, "too far") })
+		assert.Equal(t, "expected 110 to be within relative error 0.05 of 100, relative error is 0.1", f.Details)
+	})
+
+	t.Run("zero expected", func(t *testing.T) {
+		f := recoverFailure(t, func() { InEpsilon(0.0, 1e-12, 0.5, "zero") })
+		assert.Contains(t, f.Details, "relative error is +Inf")
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		f := recoverFailure(t, func() { InEpsilonSlice([]float64{1, 10}, []float64{1, 12}, 0.1, "slices differ") })
+		assert.Contains(t, f.Details, "[1]: 12 is not within relative error 0.1 of 10")
+	})
+}
+
+// TestWithinULPs tests the WithinULPs function
+func TestWithinULPs(t *testing.T) {
+
+	// Success cases
+	WithinULPs(1.0, math.Nextafter(1, 2), 1, "should not panic")
+	WithinULPs(0.0, math.Copysign(0, -1), 0, "should not panic")
+	WithinULPs(float32(1), math.Nextafter32(math.Nextafter32(1, 2), 2), 2, "should not panic")
+
+	// Across zero the distance is the sum of both sides
+	smallest := math.SmallestNonzeroFloat64
+	WithinULPs(-smallest, smallest, 2, "should not panic")
+
+	// Failure cases
+	t.Run("too far", func(t *testing.T) {
+		a, b := 0.1, 0.2
+		f := recoverFailure(t, func() { WithinULPs(a+b, 0.3, 0, "sum") })
+		assert.Equal(t, "expected 0.3 to be within 0 ULPs of 0.30000000000000004, distance is 1 ULPs", f.Details)
+
+		// The distance is measured in the precision of the type
+		next := math.Nextafter32(math.Nextafter32(1, 2), 2)
+		f = recoverFailure(t, func() { WithinULPs(float32(1), next, 1, "float32") })
+		assert.Contains(t, f.Details, "distance is 2 ULPs")
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		f := recoverFailure(t, func() { WithinULPs(math.NaN(), 1, 10, "NaN") })
+		assert.Contains(t, f.Details, "got NaN")
+	})
+}
+
+// TestNotNaNAndFinite tests the NotNaN and Finite functions
+func TestNotNaNAndFinite(t *testing.T) {
+
+	// Success cases
+	NotNaN(1.0, "should not panic")
+	NotNaN(math.Inf(1), "should not panic")
+	Finite(float32(1), "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { NotNaN(float32(math.NaN()), "NaN") })
+	assert.Equal(t, "expected a number, got NaN", f.Details)
+
+	f = recoverFailure(t, func() { Finite(math.Inf(-1), "infinite") })
+	assert.Equal(t, "expected a finite number, got -Inf", f.Details)
+	recoverFailure(t, func() { Finite(math.NaN(), "NaN") })
+}
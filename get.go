@@ -0,0 +1,52 @@
+package must
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Get returns value if err is nil and reports a failure otherwise.
+// It shortens the common pattern of calling a function and asserting it did not fail:
+//
+//	cfg := must.Get(loadConfig(path))
+//
+// If the failure policy lets execution continue, value is returned as is.
+func Get[T any](value T, err error) T {
+	if err != nil {
+		testHelper().Helper()
+		abortValues("unexpected error", fmt.Sprintf("expected no error, got: %v", err), nil, err)
+	}
+	return value
+}
+
+// Get2 is like Get for functions returning two values and an error.
+func Get2[A, B any](a A, b B, err error) (A, B) {
+	if err != nil {
+		testHelper().Helper()
+		abortValues("unexpected error", fmt.Sprintf("expected no error, got: %v", err), nil, err)
+	}
+	return a, b
+}
+
+// Do reports a failure if err is not nil. It is the counterpart of Get for functions returning only an error.
+func Do(err error) {
+	if err != nil {
+		testHelper().Helper()
+		abortValues("unexpected error", fmt.Sprintf("expected no error, got: %v", err), nil, err)
+	}
+}
+
+// OK returns value if ok is true and reports a failure otherwise.
+// It covers the comma-ok idioms: map lookups, type assertions and channel receives.
+//
+//	user, ok := users[id]
+//	name := must.OK(user, ok).Name
+//
+// The value is still returned if the failure policy lets execution continue.
+func OK[T any](value T, ok bool) T {
+	if !ok {
+		testHelper().Helper()
+		abort("unexpected not ok", fmt.Sprintf("expected ok for %v value, got false: key missing, type assertion failed or channel closed", reflect.TypeFor[T]()))
+	}
+	return value
+}
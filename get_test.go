@@ -0,0 +1,89 @@
+package must
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGet tests the Get, Get2 and Do functions
+func TestGet(t *testing.T) {
+
+	// Success cases
+	assert.Equal(t, 42, Get(strconv.Atoi("42")))
+	a, b := Get2(func() (string, int, error) { return "a", 1, nil }())
+	assert.Equal(t, "a", a)
+	assert.Equal(t, 1, b)
+	Do(nil)
+
+	// Failure cases
+	t.Run("Get error", func(t *testing.T) {
+		f := recoverFailure(t, func() { Get(strconv.Atoi("forty-two")) })
+		assert.Equal(t, "Get", f.Assertion)
+		assert.Contains(t, f.Details, `invalid syntax`)
+		assert.Contains(t, f.File, "get_test.go")
+
+		var numErr *strconv.NumError
+		require.ErrorAs(t, f.Actual.(error), &numErr)
+	})
+
+	t.Run("Get2 error", func(t *testing.T) {
+		f := recoverFailure(t, func() {
+			Get2(func() (string, int, error) { return "", 0, errors.New("boom") }())
+		})
+		assert.Equal(t, "Get2", f.Assertion)
+		assert.Equal(t, "expected no error, got: boom", f.Details)
+	})
+
+	t.Run("Do error", func(t *testing.T) {
+		f := recoverFailure(t, func() { Do(errors.New("boom")) })
+		assert.Equal(t, "Do", f.Assertion)
+	})
+
+	t.Run("value returned with log policy", func(t *testing.T) {
+		captureLog(t)
+		WithPolicy(PolicyLog, func() {
+			assert.Equal(t, 7, Get(7, errors.New("boom")))
+		})
+	})
+}
+
+// TestOK tests the OK function with comma-ok idioms
+func TestOK(t *testing.T) {
+
+	m := map[string]int{"a": 1}
+	var v any = "text"
+	ch := make(chan int, 1)
+	ch <- 5
+
+	// Success cases
+	n, ok := m["a"]
+	assert.Equal(t, 1, OK(n, ok))
+	s, ok := v.(string)
+	assert.Equal(t, "text", OK(s, ok))
+	r, ok := <-ch
+	assert.Equal(t, 5, OK(r, ok))
+
+	// Failure cases
+	t.Run("missing key", func(t *testing.T) {
+		n, ok := m["b"]
+		f := recoverFailure(t, func() { OK(n, ok) })
+		assert.Equal(t, "OK", f.Assertion)
+		assert.Contains(t, f.Details, "expected ok for int value, got false")
+	})
+
+	t.Run("failed type assertion", func(t *testing.T) {
+		e, ok := v.(error)
+		f := recoverFailure(t, func() { OK(e, ok) })
+		assert.Contains(t, f.Details, "expected ok for error value")
+	})
+
+	t.Run("closed channel", func(t *testing.T) {
+		close(ch)
+		r, ok := <-ch
+		recoverFailure(t, func() { OK(r, ok) })
+	})
+}
@@ -0,0 +1,175 @@
+package must
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OnFailure is a function type that defines the signature for functions to be called on assertion failures.
+type OnFailure func(message string, details string)
+
+// FailureHandler is called with the full description of a failed assertion.
+// Handlers must not modify the failure, it is shared with the other handlers.
+type FailureHandler func(f *Failure)
+
+// HandlerOption configures a handler registered with RegisterHandler or RegisterFailureHandler.
+type HandlerOption func(*registeredHandler)
+
+// HandlerTimeout limits how long the failure path waits for the handler.
+// The handler runs on its own goroutine; if it does not return in time it is abandoned,
+// a timeout error is added to Failure.HandlerErrors and the remaining handlers are called.
+// Such a handler receives a copy of the failure, so it may keep using it after being abandoned.
+func HandlerTimeout(d time.Duration) HandlerOption {
+	return func(r *registeredHandler) {
+		r.timeout = d
+	}
+}
+
+// registeredHandler wraps a handler so it can be identified when unregistering.
+type registeredHandler struct {
+	handle  FailureHandler
+	timeout time.Duration // Zero to wait for the handler indefinitely
+}
+
+// failureHandlers holds an immutable snapshot of the registered handlers.
+// Writers replace the snapshot while holding failureHandlersMutex, readers load it without locking.
+var (
+	failureHandlers      atomic.Pointer[[]*registeredHandler]
+	failureHandlersMutex sync.Mutex
+)
+
+func init() {
+	failureHandlers.Store(&[]*registeredHandler{})
+}
+
+// RegisterFailureHandler registers a function to be called when an assertion fails.
+// This allows for custom handling of assertion failures, such as logging or sending errors to a monitoring service.
+// After calling all registered functions, the failure policy is applied (by default the program panics).
+// The function will be called with the failure message and any additional details.
+// The returned function unregisters the handler.
+func RegisterFailureHandler(f OnFailure, opts ...HandlerOption) (unregister func()) {
+	return RegisterHandler(func(failure *Failure) {
+		f(failure.Message, failure.Details)
+	}, opts...)
+}
+
+// RegisterHandler registers a function to be called with the full *Failure when an assertion fails.
+// Handlers registered with RegisterHandler and RegisterFailureHandler are called in registration order.
+// A handler that panics, fails an assertion or times out does not prevent the other handlers and the failure policy from running,
+// the error is recorded in Failure.HandlerErrors instead.
+// The returned function unregisters the handler, calling it more than once has no effect.
+func RegisterHandler(h FailureHandler, opts ...HandlerOption) (unregister func()) {
+	entry := &registeredHandler{handle: h}
+	for _, opt := range opts {
+		opt(entry)
+	}
+
+	failureHandlersMutex.Lock()
+	defer failureHandlersMutex.Unlock()
+
+	handlers := append(slices.Clone(*failureHandlers.Load()), entry)
+	failureHandlers.Store(&handlers)
+
+	return func() {
+		failureHandlersMutex.Lock()
+		defer failureHandlersMutex.Unlock()
+
+		handlers := slices.DeleteFunc(slices.Clone(*failureHandlers.Load()), func(r *registeredHandler) bool {
+			return r == entry
+		})
+		failureHandlers.Store(&handlers)
+	}
+}
+
+// ResetFailureHandlers unregisters all handlers registered with RegisterHandler and RegisterFailureHandler.
+// Handlers installed with WithHandlers are not affected.
+func ResetFailureHandlers() {
+	failureHandlersMutex.Lock()
+	defer failureHandlersMutex.Unlock()
+
+	failureHandlers.Store(&[]*registeredHandler{})
+}
+
+// WithHandlers runs fn with additional handlers for assertions made on the calling goroutine.
+// The handlers are called after the registered ones and are removed when fn returns.
+func WithHandlers(fn func(), h ...FailureHandler) {
+	defer pushScope(&scope{handlers: slices.Clone(h)})()
+	fn()
+}
+
+// handlersFor returns the handlers to call for a failure on the given goroutine:
+// registered handlers first, then scoped handlers from the outermost scope inwards.
+// Failures raised by a handler itself are not passed to the handlers again.
+func handlersFor(gid uint64) []*registeredHandler {
+	stack := scopesOf(gid)
+	for _, s := range stack {
+		if s.inHandler {
+			return nil
+		}
+	}
+
+	handlers := *failureHandlers.Load()
+	for _, s := range stack {
+		for _, h := range s.handlers {
+			handlers = append(handlers[:len(handlers):len(handlers)], &registeredHandler{handle: h})
+		}
+	}
+	return handlers
+}
+
+// runHandlers calls the handlers for f in order and records their errors on f.
+func runHandlers(f *Failure) {
+	var errs []error
+	for i, h := range handlersFor(f.Goroutine) {
+		if err := h.run(f); err != nil {
+			errs = append(errs, fmt.Errorf("failure handler %d: %w", i, err))
+		}
+	}
+	f.HandlerErrors = errs
+}
+
+// run calls the handler, recovering from panics and enforcing its timeout.
+func (r *registeredHandler) run(f *Failure) error {
+	if r.timeout <= 0 {
+		return callHandler(r.handle, f)
+	}
+
+	// The handler may outlive the failure path, so it gets its own copy of the failure
+	c := *f
+	done := make(chan error, 1)
+	go func() {
+		done <- callHandler(r.handle, &c)
+	}()
+
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("timed out after %v", r.timeout)
+	}
+}
+
+// callHandler calls h and converts a panic into an error.
+// Assertions failed by the handler itself panic regardless of the policy in effect,
+// so they cannot exit the process or stop the goroutine before the original failure is enforced.
+func callHandler(h FailureHandler, f *Failure) (err error) {
+	defer pushScope(&scope{inHandler: true, policy: PolicyPanic})()
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = fmt.Errorf("panicked: %w", e)
+			} else {
+				err = fmt.Errorf("panicked: %v", r)
+			}
+		}
+	}()
+
+	h(f)
+	return nil
+}
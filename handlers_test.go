@@ -0,0 +1,244 @@
+package must
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegisterHandler tests that both handler kinds are called with the failure
+func TestRegisterHandler(t *testing.T) {
+
+	ResetFailureHandlers()
+	defer ResetFailureHandlers()
+
+	var calls []string
+	var received *Failure
+	RegisterFailureHandler(func(message, details string) {
+		calls = append(calls, "legacy: "+message+": "+details)
+	})
+	RegisterHandler(func(f *Failure) {
+		calls = append(calls, "structured")
+		received = f
+	})
+
+	f := recoverFailure(t, func() { False(true, "flag set") })
+
+	assert.Equal(t, []string{"legacy: flag set: expected false, got true", "structured"}, calls)
+	assert.Same(t, f, received)
+}
+
+// TestUnregisterHandler tests removing handlers
+func TestUnregisterHandler(t *testing.T) {
+
+	ResetFailureHandlers()
+	defer ResetFailureHandlers()
+
+	var calls []string
+	unregisterFirst := RegisterHandler(func(*Failure) { calls = append(calls, "first") })
+	unregisterSecond := RegisterFailureHandler(func(string, string) { calls = append(calls, "second") })
+	RegisterHandler(func(*Failure) { calls = append(calls, "third") })
+
+	unregisterFirst()
+	unregisterFirst() // no-op
+	recoverFailure(t, func() { True(false, "fail") })
+	assert.Equal(t, []string{"second", "third"}, calls)
+
+	calls = nil
+	unregisterSecond()
+	recoverFailure(t, func() { True(false, "fail") })
+	assert.Equal(t, []string{"third"}, calls)
+
+	calls = nil
+	ResetFailureHandlers()
+	recoverFailure(t, func() { True(false, "fail") })
+	assert.Empty(t, calls)
+}
+
+// TestWithHandlers tests handlers scoped to a block
+func TestWithHandlers(t *testing.T) {
+
+	ResetFailureHandlers()
+	defer ResetFailureHandlers()
+
+	var calls []string
+	RegisterHandler(func(*Failure) { calls = append(calls, "global") })
+
+	WithHandlers(func() {
+		WithHandlers(func() {
+			recoverFailure(t, func() { True(false, "fail") })
+		}, func(*Failure) { calls = append(calls, "inner") })
+	}, func(*Failure) { calls = append(calls, "outer 1") }, func(*Failure) { calls = append(calls, "outer 2") })
+
+	assert.Equal(t, []string{"global", "outer 1", "outer 2", "inner"}, calls)
+
+	// Scoped handlers are removed after the block, even if it panics
+	calls = nil
+	assert.Panics(t, func() {
+		WithHandlers(func() { True(false, "fail") }, func(*Failure) { calls = append(calls, "scoped") })
+	})
+	calls = nil
+	recoverFailure(t, func() { True(false, "fail") })
+	assert.Equal(t, []string{"global"}, calls)
+}
+
+// TestHandlersConcurrent registers handlers and fails assertions from many goroutines at once.
+// Run with -race to detect unsynchronized access to the handler list.
+func TestHandlersConcurrent(t *testing.T) {
+
+	ResetFailureHandlers()
+	defer ResetFailureHandlers()
+
+	var calls atomic.Int64
+	RegisterHandler(func(*Failure) { calls.Add(1) })
+
+	const workers = 32
+	const iterations = 50
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(2)
+
+		// Register and unregister handlers
+		go func() {
+			defer wg.Done()
+			for range iterations {
+				unregister := RegisterHandler(func(*Failure) {})
+				RegisterFailureHandler(func(string, string) {})()
+				unregister()
+			}
+		}()
+
+		// Fail assertions, with and without scoped handlers
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				func() {
+					defer func() { recover() }()
+					if i%2 == 0 {
+						Equal(i, -1, "concurrent failure")
+					} else {
+						WithHandlers(func() { Equal(i, -1, "concurrent failure") }, func(*Failure) {})
+					}
+				}()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, workers*iterations, calls.Load())
+	assert.Len(t, *failureHandlers.Load(), 1)
+}
+
+// TestHandlerIsolation tests that failing handlers do not mask the original failure
+func TestHandlerIsolation(t *testing.T) {
+
+	ResetFailureHandlers()
+	defer ResetFailureHandlers()
+
+	sentinel := errors.New("sink unavailable")
+	release := make(chan struct{})
+	defer close(release)
+
+	var calls []string
+	RegisterHandler(func(*Failure) { panic("handler bug") })
+	RegisterHandler(func(*Failure) { panic(sentinel) })
+	RegisterHandler(func(*Failure) { <-release }, HandlerTimeout(10*time.Millisecond))
+	RegisterHandler(func(*Failure) { calls = append(calls, "fast") }, HandlerTimeout(time.Second))
+	RegisterFailureHandler(func(string, string) { calls = append(calls, "last") })
+
+	f := recoverFailure(t, func() { Equal("a", "b", "original failure") })
+
+	// The remaining handlers and the policy still run
+	assert.Equal(t, []string{"fast", "last"}, calls)
+	assert.Equal(t, "original failure", f.Message)
+
+	require.Len(t, f.HandlerErrors, 3)
+	assert.EqualError(t, f.HandlerErrors[0], "failure handler 0: panicked: handler bug")
+	assert.ErrorIs(t, f.HandlerErrors[1], sentinel)
+	assert.EqualError(t, f.HandlerErrors[2], "failure handler 2: timed out after 10ms")
+
+	// A handler that fails an assertion itself does not recurse into the policy
+	ResetFailureHandlers()
+	RegisterHandler(func(*Failure) { True(false, "nested") })
+	f = recoverFailure(t, func() { True(false, "outer") })
+	assert.Equal(t, "outer", f.Message)
+	require.Len(t, f.HandlerErrors, 1)
+	nested, ok := AsFailure(f.HandlerErrors[0])
+	require.True(t, ok)
+	assert.Equal(t, "nested", nested.Message)
+}
+
+// TestAbandonedHandler tests that a handler that timed out can keep using its failure.
+// Run with -race: the failure path updates the failure while the handler still reads it.
+func TestAbandonedHandler(t *testing.T) {
+
+	ResetFailureHandlers()
+	defer ResetFailureHandlers()
+
+	seen := make(chan string, 1)
+	RegisterHandler(func(f *Failure) {
+		time.Sleep(20 * time.Millisecond)
+		seen <- fmt.Sprintf("%s %d", f.Message, len(f.HandlerErrors))
+	}, HandlerTimeout(time.Millisecond))
+
+	f := recoverFailure(t, func() { True(false, "slow sink") })
+	require.Len(t, f.HandlerErrors, 1)
+	f.Details = "changed by the caller"
+
+	assert.Equal(t, "slow sink 0", <-seen)
+}
+
+// TestNestedHandlerFailure tests that an assertion failed by a handler does not apply the policy in effect
+func TestNestedHandlerFailure(t *testing.T) {
+
+	ResetFailureHandlers()
+	defer ResetFailureHandlers()
+	defer SetPolicy(SetPolicy(PolicyPanic))
+
+	var calls []string
+	RegisterHandler(func(*Failure) { True(false, "nested") })
+	RegisterHandler(func(*Failure) { calls = append(calls, "second") })
+
+	t.Run("exit", func(t *testing.T) {
+		out := captureLog(t)
+		SetPolicy(PolicyExit)
+		calls = nil
+
+		var codes []int
+		exit = func(c int) { codes = append(codes, c) }
+		defer func() { exit = os.Exit }()
+
+		True(false, "outer")
+		assert.Equal(t, []int{DefaultExitCode}, codes)
+		assert.Equal(t, []string{"second"}, calls)
+		assert.Contains(t, out.String(), "outer")
+	})
+
+	t.Run("goexit", func(t *testing.T) {
+		out := captureLog(t)
+		SetPolicy(PolicyGoexit)
+		calls = nil
+
+		var reached bool
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			True(false, "outer")
+			reached = true
+		}()
+		wg.Wait()
+
+		assert.False(t, reached, "Expected the goroutine to stop at the original failure")
+		assert.Equal(t, []string{"second"}, calls)
+		assert.Contains(t, out.String(), "outer")
+	})
+}
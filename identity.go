@@ -0,0 +1,108 @@
+package must
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Same checks if expected and value refer to the same memory and panics if they do not.
+// Pointers, maps and channels are the same if they have the same address. Slices are the same if they
+// start at the same element of the same backing array and have the same length.
+// Values of other kinds and nil references are reported as failures.
+func Same[T any](expected, value T, message string) {
+	if p := sameProblem(expected, value, true); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// NotSame checks if expected and value refer to different memory and panics if they do not.
+// References are compared as by Same; equal content in different memory is not the same.
+func NotSame[T any](expected, value T, message string) {
+	if p := sameProblem(expected, value, false); p != nil {
+		testHelper().Helper()
+		p.report(message)
+	}
+}
+
+// sameProblem returns the problem found by Same, or by NotSame if same is false, or nil if the assertion holds.
+func sameProblem(expected, value any, same bool) *problem {
+	a, b, p := references(expected, value)
+	switch {
+	case p != nil:
+		return p
+	case same && a != b:
+		return &problem{details: fmt.Sprintf("expected %s and %s to be the same", formatReference(expected, a), formatReference(value, b)), expected: expected, actual: value, values: true}
+	case !same && a == b:
+		return &problem{details: fmt.Sprintf("expected %s and %s to not be the same", formatReference(expected, a), formatReference(value, b)), expected: expected, actual: value, values: true}
+	default:
+		return nil
+	}
+}
+
+// PointeeEqual checks if the values pointed to by expected and value are equal and panics if they are not.
+// Unlike Same it compares content, so pointers to different but equal values pass.
+func PointeeEqual[T comparable](expected, value *T, message string) {
+	if expected == nil || value == nil {
+		testHelper().Helper()
+		abort(message, "expected non-nil pointers, got nil")
+		return
+	}
+	if *expected != *value {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected pointee %s to equal %s", formatValue(*value), formatValue(*expected)), *expected, *value)
+	}
+}
+
+// reference identifies the memory a pointer, map, channel or slice refers to.
+type reference struct {
+	addr   uintptr
+	length int
+}
+
+// references returns the references of a and b, or the problem if they cannot be compared by identity.
+func references(a, b any) (reference, reference, *problem) {
+	for _, v := range []any{a, b} {
+		if !isReferenceKind(reflect.TypeOf(v)) {
+			return reference{}, reference{}, &problem{details: fmt.Sprintf("expected a pointer, slice, map or channel, got %T", v)}
+		}
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return reference{}, reference{}, &problem{details: fmt.Sprintf("expected references of the same type, got %T and %T", a, b)}
+	}
+	for _, v := range []any{a, b} {
+		if isNil(v) {
+			return reference{}, reference{}, &problem{details: "expected non-nil references, got " + describeNil(v)}
+		}
+	}
+	return referenceOf(reflect.ValueOf(a)), referenceOf(reflect.ValueOf(b)), nil
+}
+
+// isReferenceKind reports whether values of type t can be compared by identity.
+func isReferenceKind(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Map, reflect.Chan, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+// referenceOf returns the reference of a non-nil pointer, map, channel or slice.
+func referenceOf(v reflect.Value) reference {
+	r := reference{addr: v.Pointer()}
+	if v.Kind() == reflect.Slice {
+		r.length = v.Len()
+	}
+	return r
+}
+
+// formatReference formats a reference with its type and address for failure details.
+func formatReference(v any, r reference) string {
+	if reflect.TypeOf(v).Kind() == reflect.Slice {
+		return fmt.Sprintf("%T at %#x (length %d)", v, r.addr, r.length)
+	}
+	return fmt.Sprintf("%T at %#x", v, r.addr)
+}
@@ -0,0 +1,82 @@
+package must
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSame tests the Same and NotSame functions
+func TestSame(t *testing.T) {
+
+	a, b := 1, 1
+	slice := []int{1, 2, 3}
+	m := map[string]int{"a": 1}
+	ch := make(chan int)
+
+	// Success cases
+	Same(&a, &a, "should not panic")
+	Same(slice, slice, "should not panic")
+	Same(m, m, "should not panic")
+	Same(ch, ch, "should not panic")
+	NotSame(&a, &b, "should not panic")
+	NotSame(slice, slice[:2], "should not panic")
+	NotSame(slice, []int{1, 2, 3}, "should not panic")
+	NotSame(m, map[string]int{"a": 1}, "should not panic")
+
+	// Failure cases
+	t.Run("Same", func(t *testing.T) {
+		f := recoverFailure(t, func() { Same(&a, &b, "equal but distinct") })
+		assert.Equal(t, "Same", f.Assertion)
+		assert.Regexp(t, `^expected \*int at 0x[0-9a-f]+ and \*int at 0x[0-9a-f]+ to be the same$`, f.Details)
+
+		f = recoverFailure(t, func() { Same(slice, slice[1:], "different start") })
+		assert.Regexp(t, `^expected \[\]int at 0x[0-9a-f]+ \(length 3\) and \[\]int at 0x[0-9a-f]+ \(length 2\) to be the same$`, f.Details)
+	})
+
+	t.Run("NotSame", func(t *testing.T) {
+		f := recoverFailure(t, func() { NotSame(m, m, "same map") })
+		assert.Equal(t, "NotSame", f.Assertion)
+		assert.Regexp(t, `^expected map\[string\]int at 0x[0-9a-f]+ and map\[string\]int at 0x[0-9a-f]+ to not be the same$`, f.Details)
+	})
+
+	t.Run("invalid references", func(t *testing.T) {
+		f := recoverFailure(t, func() { Same(1, 1, "not a reference") })
+		assert.Equal(t, "expected a pointer, slice, map or channel, got int", f.Details)
+
+		f = recoverFailure(t, func() { Same[any](&a, slice, "different types") })
+		assert.Equal(t, "expected references of the same type, got *int and []int", f.Details)
+
+		f = recoverFailure(t, func() { NotSame(&a, nil, "nil") })
+		assert.Equal(t, "expected non-nil references, got nil pointer of type *int", f.Details)
+	})
+}
+
+// TestPointeeEqual tests the PointeeEqual function
+func TestPointeeEqual(t *testing.T) {
+
+	a, b, c := "x", "x", "y"
+
+	// Success case
+	PointeeEqual(&a, &b, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { PointeeEqual(&a, &c, "different values") })
+	assert.Equal(t, "PointeeEqual", f.Assertion)
+	assert.Equal(t, `expected pointee "y" to equal "x"`, f.Details)
+	assert.Equal(t, "x", f.Expected)
+	assert.Equal(t, "y", f.Actual)
+
+	f = recoverFailure(t, func() { PointeeEqual(&a, nil, "nil") })
+	assert.Equal(t, "expected non-nil pointers, got nil", f.Details)
+}
+
+// TestPointsToSameIdentity tests that the deprecated pointer assertions compare identity
+func TestPointsToSameIdentity(t *testing.T) {
+
+	a, b := "same", "same"
+
+	f := recoverFailure(t, func() { PointsToSame(&a, &b, "equal but distinct") })
+	assert.Equal(t, "PointsToSame", f.Assertion)
+	PointsToNotSame(&a, &b, "should not panic")
+}
@@ -0,0 +1,87 @@
+package must
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Len checks if the given value (see NotEmpty for the supported types) has exactly n elements and panics if it does not.
+func Len(value any, n int, message string) {
+	kind, length, ok := lengthOf(value)
+	if !ok {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("expected a map, slice, array, channel or string, got %T", value))
+		return
+	}
+	if length != n {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s of length %d, got length %d", kind, n, length), n, length)
+	}
+}
+
+// MinLen checks if the given value has at least n elements and panics if it does not.
+func MinLen(value any, n int, message string) {
+	kind, length, ok := lengthOf(value)
+	if !ok {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("expected a map, slice, array, channel or string, got %T", value))
+		return
+	}
+	if length < n {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s of length at least %d, got length %d", kind, n, length), n, length)
+	}
+}
+
+// MaxLen checks if the given value has at most n elements and panics if it does not.
+func MaxLen(value any, n int, message string) {
+	kind, length, ok := lengthOf(value)
+	if !ok {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("expected a map, slice, array, channel or string, got %T", value))
+		return
+	}
+	if length > n {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s of length at most %d, got length %d", kind, n, length), n, length)
+	}
+}
+
+// lengthOf returns a name for the kind of value and its length. Pointers are followed, a nil pointer has length zero.
+// It reports false if the value is not a map, slice, array, channel or string.
+func lengthOf(value any) (kind string, length int, ok bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			// Follow the type alone to check that it points to a supported kind
+			t := v.Type()
+			for t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			kind, ok = kindName(t.Kind())
+			return kind, 0, ok
+		}
+		v = v.Elem()
+	}
+	if kind, ok = kindName(v.Kind()); !ok {
+		return "", 0, false
+	}
+	return kind, v.Len(), true
+}
+
+// kindName names the kinds that have a length.
+func kindName(k reflect.Kind) (string, bool) {
+	switch k {
+	case reflect.Map:
+		return "map", true
+	case reflect.Slice:
+		return "slice", true
+	case reflect.Array:
+		return "array", true
+	case reflect.Chan:
+		return "channel", true
+	case reflect.String:
+		return "string", true
+	}
+	return "", false
+}
@@ -0,0 +1,51 @@
+package must
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLengthAssertions tests the Len, MinLen and MaxLen functions
+func TestLengthAssertions(t *testing.T) {
+
+	// Success cases
+	Len([]int{1, 2}, 2, "should not panic")
+	Len(map[string]int{"a": 1}, 1, "should not panic")
+	Len("héllo", 6, "should not panic")
+	Len([3]bool{}, 3, "should not panic")
+	Len((*[]int)(nil), 0, "should not panic")
+	MinLen([]string{"a", "b"}, 2, "should not panic")
+	MaxLen([]string{"a", "b"}, 2, "should not panic")
+
+	ch := make(chan int, 2)
+	ch <- 1
+	Len(ch, 1, "should not panic")
+
+	// Failure cases
+	t.Run("Len", func(t *testing.T) {
+		f := recoverFailure(t, func() { Len([]int{1, 2}, 3, "wrong length") })
+		assert.Equal(t, "Len", f.Assertion)
+		assert.Equal(t, "expected slice of length 3, got length 2", f.Details)
+		assert.Equal(t, 3, f.Expected)
+		assert.Equal(t, 2, f.Actual)
+	})
+
+	t.Run("MinLen", func(t *testing.T) {
+		f := recoverFailure(t, func() { MinLen(map[int]int{1: 1}, 2, "too short") })
+		assert.Equal(t, "expected map of length at least 2, got length 1", f.Details)
+	})
+
+	t.Run("MaxLen", func(t *testing.T) {
+		f := recoverFailure(t, func() { MaxLen("abc", 2, "too long") })
+		assert.Equal(t, "expected string of length at most 2, got length 3", f.Details)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		f := recoverFailure(t, func() { Len(42, 0, "unsupported") })
+		assert.Equal(t, "expected a map, slice, array, channel or string, got int", f.Details)
+
+		recoverFailure(t, func() { MinLen((*int)(nil), 0, "unsupported") })
+		recoverFailure(t, func() { MaxLen(nil, 0, "unsupported") })
+	})
+}
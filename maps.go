@@ -0,0 +1,125 @@
+package must
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// MapHasAll checks if m has every one of the given keys and panics if not. The failure details list all missing keys.
+func MapHasAll[K comparable, V any](m map[K]V, keys []K, message string) {
+	var missing []string
+	total := 0
+	for _, key := range distinct(keys) {
+		if _, ok := m[key]; !ok {
+			missing = appendLimited(missing, formatValue(key))
+			total++
+		}
+	}
+	if total > 0 {
+		testHelper().Helper()
+		abortValues(message, formatList("expected map to have all keys", "missing key", "missing keys", missing, total), keys, m)
+	}
+}
+
+// MapValueEquals checks if m has key with a value equal to expected and panics if not.
+func MapValueEquals[K, V comparable](m map[K]V, key K, expected V, message string) {
+	value, ok := m[key]
+	if !ok {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected map to have key %s, but it does not", formatValue(key)), expected, nil)
+		return
+	}
+	if value != expected {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected value at key %s to be %s, got %s", formatValue(key), formatValue(expected), formatValue(value)), expected, value)
+	}
+}
+
+// MapEqual checks if value has the same entries as expected and panics if not.
+// The failure details list the missing, extra and changed entries by key.
+func MapEqual[K, V comparable](expected, value map[K]V, message string) {
+	var found []keyedDiff
+	for key, e := range expected {
+		v, ok := value[key]
+		switch {
+		case !ok:
+			found = append(found, keyedDiff{formatMapKey(key), fmt.Sprintf("missing [%s]: %s", formatValue(key), formatValue(e))})
+		case e != v:
+			found = append(found, keyedDiff{formatMapKey(key), fmt.Sprintf("changed [%s]: %s != %s", formatValue(key), formatValue(e), formatValue(v))})
+		}
+	}
+	for key, v := range value {
+		if _, ok := expected[key]; !ok {
+			found = append(found, keyedDiff{formatMapKey(key), fmt.Sprintf("extra [%s]: %s", formatValue(key), formatValue(v))})
+		}
+	}
+	if len(found) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatDifferences("expected maps to be equal", sortDiffs(found), len(found)), expected, value)
+	}
+}
+
+// MapKeysExactly checks if the keys of m are exactly the given keys, in any order, and panics if not.
+// The failure details list the missing and extra keys.
+func MapKeysExactly[K comparable, V any](m map[K]V, keys []K, message string) {
+	expected := make(map[K]bool, len(keys))
+	for _, key := range keys {
+		expected[key] = true
+	}
+	var diffs []string
+	total := 0
+	for _, key := range distinct(keys) {
+		if _, ok := m[key]; !ok {
+			diffs = appendLimited(diffs, "missing "+formatValue(key))
+			total++
+		}
+	}
+	var extra []keyedDiff
+	for key := range m {
+		if !expected[key] {
+			extra = append(extra, keyedDiff{formatMapKey(key), "extra " + formatValue(key)})
+		}
+	}
+	if total+len(extra) > 0 {
+		for _, diff := range sortDiffs(extra) {
+			diffs = appendLimited(diffs, diff)
+		}
+		testHelper().Helper()
+		abortValues(message, formatDifferences("expected map keys to match", diffs, total+len(extra)), keys, m)
+	}
+}
+
+// MapLen checks if m has exactly n entries and panics if it does not.
+func MapLen[K comparable, V any](m map[K]V, n int, message string) {
+	if len(m) != n {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected map of length %d, got length %d", n, len(m)), n, len(m))
+	}
+}
+
+// keyedDiff is a difference found at a map key, with the key formatted for ordering.
+type keyedDiff struct {
+	key  string
+	text string
+}
+
+// sortDiffs orders differences found by iterating a map by their keys and returns their limited texts.
+func sortDiffs(found []keyedDiff) []string {
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].key != found[j].key {
+			return found[i].key < found[j].key
+		}
+		return found[i].text < found[j].text
+	})
+	var diffs []string
+	for _, diff := range found {
+		diffs = appendLimited(diffs, diff.text)
+	}
+	return diffs
+}
+
+// formatMapKey formats a map key for ordering, see formatKey.
+func formatMapKey[K comparable](key K) string {
+	return formatKey(reflect.ValueOf(&key).Elem())
+}
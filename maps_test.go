@@ -0,0 +1,106 @@
+package must
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMapHasAll tests the MapHasAll function
+func TestMapHasAll(t *testing.T) {
+
+	config := map[string]string{"host": "localhost", "port": "8080"}
+
+	// Success case
+	MapHasAll(config, []string{"host", "port"}, "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() { MapHasAll(config, []string{"user", "host", "password", "user"}, "incomplete") })
+	assert.Equal(t, "MapHasAll", f.Assertion)
+	assert.Equal(t, "expected map to have all keys, found 2 missing keys:\n\t\"user\"\n\t\"password\"", f.Details)
+}
+
+// TestMapValueEquals tests the MapValueEquals function
+func TestMapValueEquals(t *testing.T) {
+
+	config := map[string]int{"port": 8080}
+
+	// Success case
+	MapValueEquals(config, "port", 8080, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { MapValueEquals(config, "port", 443, "wrong port") })
+	assert.Equal(t, "MapValueEquals", f.Assertion)
+	assert.Equal(t, `expected value at key "port" to be 443, got 8080`, f.Details)
+	assert.Equal(t, 443, f.Expected)
+	assert.Equal(t, 8080, f.Actual)
+
+	f = recoverFailure(t, func() { MapValueEquals(config, "timeout", 30, "missing") })
+	assert.Equal(t, `expected map to have key "timeout", but it does not`, f.Details)
+}
+
+// TestMapEqual tests the MapEqual function
+func TestMapEqual(t *testing.T) {
+
+	// Success cases
+	MapEqual(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1}, "should not panic")
+	MapEqual(map[string]int{}, nil, "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() {
+		MapEqual(map[string]int{"a": 1, "b": 2, "c": 3}, map[string]int{"a": 1, "b": 5, "d": 4}, "different")
+	})
+	assert.Equal(t, "MapEqual", f.Assertion)
+	assert.Equal(t, "expected maps to be equal, found 3 differences:"+
+		"\n\tchanged [\"b\"]: 2 != 5"+
+		"\n\tmissing [\"c\"]: 3"+
+		"\n\textra [\"d\"]: 4", f.Details)
+
+	t.Run("keys that print the same", func(t *testing.T) {
+		MapEqual(map[any]int{1: 1, int64(1): 2, nil: 3}, map[any]int{nil: 3, int64(1): 2, 1: 1}, "should not panic")
+
+		f := recoverFailure(t, func() {
+			MapEqual(map[any]int{1: 1, int64(1): 2}, map[any]int{1: 1, int64(1): 3}, "different")
+		})
+		assert.Equal(t, "expected maps to be equal, found 1 difference:\n\tchanged [1]: 2 != 3", f.Details)
+
+		f = recoverFailure(t, func() { MapEqual(map[any]int{nil: 1}, map[any]int{nil: 2}, "nil key") })
+		assert.Equal(t, "expected maps to be equal, found 1 difference:\n\tchanged [<nil>]: 1 != 2", f.Details)
+	})
+}
+
+// TestMapKeysExactly tests the MapKeysExactly function
+func TestMapKeysExactly(t *testing.T) {
+
+	m := map[int]bool{1: true, 2: false}
+
+	// Success case
+	MapKeysExactly(m, []int{2, 1}, "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() { MapKeysExactly(m, []int{1, 3}, "different keys") })
+	assert.Equal(t, "MapKeysExactly", f.Assertion)
+	assert.Equal(t, "expected map keys to match, found 2 differences:\n\tmissing 3\n\textra 2", f.Details)
+
+	t.Run("keys that print the same", func(t *testing.T) {
+		MapKeysExactly(map[any]int{1: 1, int64(1): 2, nil: 3}, []any{nil, int64(1), 1}, "should not panic")
+
+		f := recoverFailure(t, func() { MapKeysExactly(map[any]int{1: 1, int64(1): 2}, []any{1}, "extra key") })
+		assert.Equal(t, "expected map keys to match, found 1 difference:\n\textra 1", f.Details)
+
+		f = recoverFailure(t, func() { MapKeysExactly(map[any]int{nil: 1}, []any{1}, "nil key") })
+		assert.Equal(t, "expected map keys to match, found 2 differences:\n\tmissing 1\n\textra <nil>", f.Details)
+	})
+}
+
+// TestMapLen tests the MapLen function
+func TestMapLen(t *testing.T) {
+
+	// Success case
+	MapLen(map[string]int{"a": 1}, 1, "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() { MapLen(map[string]int{}, 2, "wrong length") })
+	assert.Equal(t, "MapLen", f.Assertion)
+	assert.Equal(t, "expected map of length 2, got length 0", f.Details)
+}
@@ -1,71 +1,25 @@
 package must
 
 import (
+	"cmp"
 	"fmt"
-	"os"
 	"slices"
-	"sync"
-	"unsafe"
 )
 
-// OnFailure is a function type that defines the signature for functions to be called on assertion failures.
-type OnFailure func(message string, details string)
-
-var (
-	failureHandlers      []OnFailure = []OnFailure{}
-	failureHandlersMutex sync.Mutex
-)
-
-// RegisterFailureHandler registers a function to be called when an assertion fails.
-// This allows for custom handling of assertion failures, such as logging or sending errors to a monitoring service.
-// After calling all registered functions, the program will panic with the failure message.
-// The function will be called with the failure message and any additional details.
-func RegisterFailureHandler(f OnFailure) {
-	failureHandlersMutex.Lock()
-	defer failureHandlersMutex.Unlock()
-
-	failureHandlers = append(failureHandlers, f)
-}
-
-// abort is a helper function that panics with a message and details.
-// It is used internally by the assertion functions to handle assertion failures.
-func abort(message string, details string) {
-	for _, f := range failureHandlers {
-		f(message, details)
-	}
-	panic(message + ": " + fmt.Sprint(details))
-}
-
 // NotNil checks if the given value is nil and panics if it is.
-// It validates if the interface of the value is not nil and if the underlying value is not nil.
-// This handles cases like nil pointers where the interface is not nil but the underlying value is.
-// Uses unsafe to check the internal representation of the interface without reflection.
+// Besides a nil interface, a typed nil stored in the interface is detected as well:
+// nil pointers, maps, slices, channels, funcs and unsafe pointers.
 func NotNil(value any, message string) {
-	// First, check if the interface itself is nil
-	if value == nil {
-		abort(message, "expected a non-nil value, got nil")
-	}
-
-	// Check if the data pointer inside the interface is nil (e.g., *string(nil))
-	// In Go, an interface value is represented by a header with two words:
-	// a pointer to type information and a pointer to the actual data
-	type eface struct {
-		_type unsafe.Pointer
-		data  unsafe.Pointer
-	}
-
-	// Convert the interface to our internal representation to check the data pointer
-	valuePtr := (*eface)(unsafe.Pointer(&value)) // #nosec G103
-
-	// If the data pointer is nil, it means the interface contains a nil pointer value
-	if valuePtr.data == nil && valuePtr._type != nil {
-		abort(message, fmt.Sprintf("expected a non-nil value, got nil pointer of type %T", value))
+	if isNil(value) {
+		testHelper().Helper()
+		abort(message, "expected a non-nil value, got "+describeNil(value))
 	}
 }
 
 // NoError checks if the given error is nil and panics if it is not.
 func NoError(err error, message string) {
 	if err != nil {
+		testHelper().Helper()
 		abort(message, fmt.Sprintf("expected no error, got: %v", err))
 	}
 }
@@ -73,6 +27,7 @@ func NoError(err error, message string) {
 // Error checks if the given error is not nil and panics if it is.
 func Error(err error, message string) {
 	if err == nil {
+		testHelper().Helper()
 		abort(message, "expected an error, got nil")
 	}
 }
@@ -81,7 +36,8 @@ func Error(err error, message string) {
 // It is used to ensure that two values are not equal before proceeding with further operations.
 func NotEqual[T comparable](expected, value T, message string) {
 	if expected == value {
-		abort(message, fmt.Sprintf("expected %v to not be equal to %v", expected, value))
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to not be equal to %v", expected, value), expected, value)
 	}
 }
 
@@ -89,7 +45,8 @@ func NotEqual[T comparable](expected, value T, message string) {
 // It is used to ensure that two values are equal before proceeding with further operations.
 func Equal[T comparable](expected, value T, message string) {
 	if expected != value {
-		abort(message, fmt.Sprintf("expected %v to be equal to %v", expected, value))
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be equal to %v", expected, value), expected, value)
 	}
 }
 
@@ -97,6 +54,7 @@ func Equal[T comparable](expected, value T, message string) {
 // It is used to ensure that a boolean condition is true before proceeding with further operations.
 func True(value bool, message string) {
 	if !value {
+		testHelper().Helper()
 		abort(message, "expected true, got false")
 	}
 }
@@ -105,76 +63,140 @@ func True(value bool, message string) {
 // It is used to ensure that a boolean condition is false before proceeding with further operations.
 func False(value bool, message string) {
 	if value {
+		testHelper().Helper()
 		abort(message, "expected false, got true")
 	}
 }
 
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
 // NotZero checks if the given value is zero and panics if it is.
 // It is used to ensure that a numeric value is not zero before proceeding with further operations.
-func NotZero[T ~int | float64](value T, message string) {
+func NotZero[T Number](value T, message string) {
 	if value == 0 {
+		testHelper().Helper()
 		abort(message, "expected non-zero value, got zero")
 	}
 }
 
-func GreaterThan[T ~int | float64](value, threshold T, message string) {
-	if value <= threshold {
-		abort(message, fmt.Sprintf("expected %v to be greater than %v", value, threshold))
+// The ordering assertions accept any ordered type: integers, floats and strings, including custom types over them.
+// A NaN never satisfies an ordering assertion.
+
+// GreaterThan checks if value is greater than threshold and panics if it is not.
+func GreaterThan[T cmp.Ordered](value, threshold T, message string) {
+	if !(value > threshold) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be greater than %v", value, threshold), threshold, value)
 	}
 }
-func LessThan[T ~int | float64](value, threshold T, message string) {
-	if value >= threshold {
-		abort(message, fmt.Sprintf("expected %v to be less than %v", value, threshold))
+
+// LessThan checks if value is less than threshold and panics if it is not.
+func LessThan[T cmp.Ordered](value, threshold T, message string) {
+	if !(value < threshold) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be less than %v", value, threshold), threshold, value)
 	}
 }
-func GreaterThanOrEqual[T ~int | float64](value, threshold T, message string) {
-	if value < threshold {
-		abort(message, fmt.Sprintf("expected %v to be greater than or equal to %v", value, threshold))
+
+// GreaterThanOrEqual checks if value is greater than or equal to threshold and panics if it is not.
+func GreaterThanOrEqual[T cmp.Ordered](value, threshold T, message string) {
+	if !(value >= threshold) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be greater than or equal to %v", value, threshold), threshold, value)
 	}
 }
-func LessThanOrEqual[T ~int | float64](value, threshold T, message string) {
-	if value > threshold {
-		abort(message, fmt.Sprintf("expected %v to be less than or equal to %v", value, threshold))
+
+// LessThanOrEqual checks if value is less than or equal to threshold and panics if it is not.
+func LessThanOrEqual[T cmp.Ordered](value, threshold T, message string) {
+	if !(value <= threshold) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be less than or equal to %v", value, threshold), threshold, value)
+	}
+}
+
+// Bounds selects which ends of a range are included by InRange.
+type Bounds int
+
+const (
+	// Inclusive includes both ends: [low, high].
+	Inclusive Bounds = iota
+	// Exclusive excludes both ends: (low, high).
+	Exclusive
+	// LowInclusive includes the low end only: [low, high).
+	LowInclusive
+	// HighInclusive includes the high end only: (low, high].
+	HighInclusive
+)
+
+// Between checks if value is within [low, high], both ends included, and panics if it is not.
+func Between[T cmp.Ordered](value, low, high T, message string) {
+	if !(value >= low && value <= high) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be between %v and %v", value, low, high), [2]T{low, high}, value)
 	}
 }
 
-// NotEmpty checks if the given value (map, slice or string) is empty and panics if it is.
+// InRange checks if value is within the range from low to high, with the ends included as selected by bounds,
+// and panics if it is not.
+func InRange[T cmp.Ordered](value, low, high T, bounds Bounds, message string) {
+	lowOK, highOK := value > low, value < high
+	if bounds == Inclusive || bounds == LowInclusive {
+		lowOK = value >= low
+	}
+	if bounds == Inclusive || bounds == HighInclusive {
+		highOK = value <= high
+	}
+	if !lowOK || !highOK {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be in range %s", value, bounds.format(low, high)), [2]T{low, high}, value)
+	}
+}
+
+// format renders a range with the given ends in interval notation.
+func (b Bounds) format(low, high any) string {
+	open, closing := "(", ")"
+	if b == Inclusive || b == LowInclusive {
+		open = "["
+	}
+	if b == Inclusive || b == HighInclusive {
+		closing = "]"
+	}
+	return fmt.Sprintf("%s%v, %v%s", open, low, high, closing)
+}
+
+// NotEmpty checks if the given value is empty and panics if it is.
+// It accepts any map, slice, array, channel or string type, and pointers to them, where a nil pointer is empty.
+// The length of a channel is the number of elements queued in its buffer.
 func NotEmpty(value any, message string) {
-	switch v := value.(type) {
-	case map[any]any:
-		if len(v) == 0 {
-			abort(message, "expected a non-empty map, got empty")
-		}
-	case []any:
-		if len(v) == 0 {
-			abort(message, "expected a non-empty slice, got empty")
-		}
-	case string:
-		if v == "" {
-			abort(message, "expected a non-empty string, got empty")
-		}
-	default:
-		abort(message, fmt.Sprintf("expected a map, slice or string, got %T", v))
-	}
-}
-
-// Empty checks if the given value (map, slice or string) is not empty and panics if it is not.
+	kind, length, ok := lengthOf(value)
+	if !ok {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("expected a map, slice, array, channel or string, got %T", value))
+		return
+	}
+	if length == 0 {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("expected a non-empty %s, got empty", kind))
+	}
+}
+
+// Empty checks if the given value is not empty and panics if it is not.
+// It accepts the same types as NotEmpty.
 func Empty(value any, message string) {
-	switch v := value.(type) {
-	case map[any]any:
-		if len(v) != 0 {
-			abort(message, "expected an empty map, got non-empty")
-		}
-	case []any:
-		if len(v) != 0 {
-			abort(message, "expected an empty slice, got non-empty")
-		}
-	case string:
-		if v != "" {
-			abort(message, "expected an empty string, got non-empty")
-		}
-	default:
-		abort(message, fmt.Sprintf("expected a map, slice or string, got %T", v))
+	kind, length, ok := lengthOf(value)
+	if !ok {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("expected a map, slice, array, channel or string, got %T", value))
+		return
+	}
+	if length != 0 {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("expected an empty %s, got length %d", kind, length))
 	}
 }
 
@@ -183,6 +205,7 @@ func Contains[T comparable](slice []T, value T, message string) {
 	if slices.Contains(slice, value) {
 		return
 	}
+	testHelper().Helper()
 	abort(message, fmt.Sprintf("expected slice to contain %v, but it does not", value))
 }
 
@@ -191,39 +214,25 @@ func NotContains[T comparable](slice []T, value T, message string) {
 	if !slices.Contains(slice, value) {
 		return
 	}
+	testHelper().Helper()
 	abort(message, fmt.Sprintf("expected slice to not contain %v, but it does", value))
 }
 
 // IsNil checks if the given value is nil and panics if it is not.
+// Typed nils such as a nil pointer stored in the interface are nil, as for NotNil.
 func IsNil(value any, message string) {
-	if value != nil {
-		abort(message, "expected nil, got non-nil")
+	if !isNil(value) {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("expected nil, got non-nil %T", value))
 	}
 }
 
 // IsNotNil checks if the given value is not nil and panics if it is.
+// It detects typed nils in the same way as NotNil.
 func IsNotNil(value any, message string) {
-	if value == nil {
-		abort(message, "expected non-nil, got nil")
-	}
-}
-
-// FileExists checks if the given file path exists and panics if it does not.
-// It is used to ensure that a file exists before proceeding with further operations.
-func FileExists(path string, message string) {
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		abort(message, fmt.Sprintf("expected file %s to exist, but it does not", path))
-	}
-}
-
-// DirExists checks if the given directory path exists and panics if it does not.
-// It is used to ensure that a directory exists before proceeding with further operations.
-func DirExists(path string, message string) {
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		abort(message, fmt.Sprintf("expected directory %s to exist, but it does not", path))
-	}
-	if info, err := os.Stat(path); err == nil && !info.IsDir() {
-		abort(message, fmt.Sprintf("expected %s to be a directory, but it is not", path))
+	if isNil(value) {
+		testHelper().Helper()
+		abort(message, "expected non-nil, got "+describeNil(value))
 	}
 }
 
@@ -231,6 +240,7 @@ func DirExists(path string, message string) {
 // It is used to ensure that a value is of a specific type before proceeding with further operations.
 func TypeOf[T any](value any, message string) {
 	if _, ok := value.(T); !ok {
+		testHelper().Helper()
 		abort(message, fmt.Sprintf("expected value of type %T, got %T", (*T)(nil), value))
 	}
 }
@@ -239,65 +249,76 @@ func TypeOf[T any](value any, message string) {
 // It is used to ensure that a value is not of a specific type before proceeding with further operations.
 func TypeOfNot[T any](value any, message string) {
 	if _, ok := value.(T); ok {
+		testHelper().Helper()
 		abort(message, fmt.Sprintf("expected value not of type %T, got %T", (*T)(nil), value))
 	}
 }
 
-// PointsToSame checks if two pointers point to the same value and panics if they do not.
+// PointsToSame checks if two pointers point to the same memory and panics if they do not.
+//
+// Deprecated: Use Same, which also works for slices, maps and channels. Before it was deprecated,
+// PointsToSame compared the values the pointers point to; use PointeeEqual for that.
 func PointsToSame[T comparable](a, b *T, message string) {
-	if a == nil || b == nil { // nolint:staticcheck
-		abort(message, "expected non-nil pointers, got nil")
-	}
-	if *a != *b { // nolint:staticcheck
-		abort(message, fmt.Sprintf("expected pointers to point to the same value, got %v and %v", *a, *b))
+	if p := sameProblem(a, b, true); p != nil {
+		testHelper().Helper()
+		p.report(message)
 	}
 }
 
+// PointsToNotSame checks if two pointers point to different memory and panics if they do not.
+//
+// Deprecated: Use NotSame. Before it was deprecated, PointsToNotSame compared the values
+// the pointers point to; use NotEqual(*a, *b, message) for that.
 func PointsToNotSame[T comparable](a, b *T, message string) {
-	if a == nil || b == nil { // nolint:staticcheck
-		abort(message, "expected non-nil pointers, got nil")
-	}
-	if *a == *b { // nolint:staticcheck
-		abort(message, fmt.Sprintf("expected pointers to point to different values, got %v and %v", *a, *b))
+	if p := sameProblem(a, b, false); p != nil {
+		testHelper().Helper()
+		p.report(message)
 	}
 }
 
 func SliceHas[T comparable](slice []T, value T, message string) {
 	if !slices.Contains(slice, value) {
+		testHelper().Helper()
 		abort(message, fmt.Sprintf("expected slice to have %v, but it does not", value))
 	}
 }
 func SliceNotHas[T comparable](slice []T, value T, message string) {
 	if slices.Contains(slice, value) {
+		testHelper().Helper()
 		abort(message, fmt.Sprintf("expected slice to not have %v, but it does", value))
 	}
 }
 
 func MapHas[K comparable, V any](m map[K]V, key K, message string) {
 	if _, ok := m[key]; !ok {
+		testHelper().Helper()
 		abort(message, fmt.Sprintf("expected map to have key %v, but it does not", key))
 	}
 }
 func MapNotHas[K comparable, V any](m map[K]V, key K, message string) {
 	if _, ok := m[key]; ok {
+		testHelper().Helper()
 		abort(message, fmt.Sprintf("expected map to not have key %v, but it does", key))
 	}
 }
 
 func MapNotEmpty[K comparable, V any](m map[K]V, message string) {
 	if len(m) == 0 {
+		testHelper().Helper()
 		abort(message, "expected map to be non-empty, but it is empty")
 	}
 }
 
 func MapEmpty[K comparable, V any](m map[K]V, message string) {
 	if len(m) != 0 {
+		testHelper().Helper()
 		abort(message, "expected map to be empty, but it is not")
 	}
 }
 
 func IsEmpty[T comparable](slice []T, message string) {
 	if len(slice) != 0 {
+		testHelper().Helper()
 		abort(message, "expected slice to be empty, but it is not")
 	}
 }
@@ -2,6 +2,7 @@ package must
 
 import (
 	"errors"
+	"math"
 	"os"
 	"testing"
 
@@ -12,12 +13,9 @@ import (
 // TestRegisterFailureHandler tests the RegisterFailureHandler function
 func TestRegisterFailureHandler(t *testing.T) {
 
-	// Save the original failure handlers and restore them after the test
-	originalHandlers := failureHandlers
-	defer func() { failureHandlers = originalHandlers }()
-
-	// Reset failure handlers for this test
-	failureHandlers = []OnFailure{}
+	// Reset failure handlers for this test and after it
+	ResetFailureHandlers()
+	defer ResetFailureHandlers()
 
 	// Create a test failure handler that records whether it was called
 	var handlerCalled bool
@@ -31,7 +29,7 @@ func TestRegisterFailureHandler(t *testing.T) {
 	RegisterFailureHandler(testHandler)
 
 	// Verify the handler was registered
-	require.Len(t, failureHandlers, 1)
+	require.Len(t, *failureHandlers.Load(), 1)
 
 	// Test that the handler is called when abort is called
 	defer func() {
@@ -358,6 +356,80 @@ func TestNumericAssertions(t *testing.T) {
 	})
 }
 
+// TestOrderedAssertions tests the ordering functions with other ordered types
+func TestOrderedAssertions(t *testing.T) {
+
+	type duration int64
+
+	// Success cases
+	NotZero(int64(1), "should not panic")
+	NotZero(uint32(1), "should not panic")
+	NotZero(float32(0.5), "should not panic")
+	NotZero(duration(1), "should not panic")
+	GreaterThan(int64(2), 1, "should not panic")
+	GreaterThan(uint8(2), 1, "should not panic")
+	LessThan(float32(1.5), 2, "should not panic")
+	LessThan(duration(1), duration(2), "should not panic")
+	GreaterThanOrEqual("b", "a", "should not panic")
+	LessThanOrEqual("a", "a", "should not panic")
+
+	// Failure cases
+	t.Run("custom type", func(t *testing.T) {
+		f := recoverFailure(t, func() { GreaterThan(duration(1), duration(2), "should panic") })
+		assert.Equal(t, "expected 1 to be greater than 2", f.Details)
+		assert.Equal(t, duration(2), f.Expected)
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		assert.Panics(t, func() { LessThan("b", "a", "should panic") })
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		nan := math.NaN()
+		assert.Panics(t, func() { GreaterThan(nan, 0, "should panic") })
+		assert.Panics(t, func() { LessThan(nan, 0, "should panic") })
+		assert.Panics(t, func() { GreaterThanOrEqual(nan, 0, "should panic") })
+		assert.Panics(t, func() { LessThanOrEqual(nan, 0, "should panic") })
+		assert.Panics(t, func() { Between(nan, 0, 1, "should panic") })
+	})
+}
+
+// TestRangeAssertions tests the Between and InRange functions
+func TestRangeAssertions(t *testing.T) {
+
+	// Success cases
+	Between(1, 1, 3, "should not panic")
+	Between(3, 1, 3, "should not panic")
+	Between("m", "a", "z", "should not panic")
+	InRange(1, 1, 3, Inclusive, "should not panic")
+	InRange(2, 1, 3, Exclusive, "should not panic")
+	InRange(1, 1, 3, LowInclusive, "should not panic")
+	InRange(3, 1, 3, HighInclusive, "should not panic")
+	InRange(0.5, 0, 1, Exclusive, "should not panic")
+
+	// Failure cases
+	t.Run("Between", func(t *testing.T) {
+		f := recoverFailure(t, func() { Between(4, 1, 3, "should panic") })
+		assert.Equal(t, "expected 4 to be between 1 and 3", f.Details)
+		assert.Equal(t, [2]int{1, 3}, f.Expected)
+		assert.Panics(t, func() { Between(0, 1, 3, "should panic") })
+	})
+
+	t.Run("InRange", func(t *testing.T) {
+		f := recoverFailure(t, func() { InRange(1, 1, 3, Exclusive, "should panic") })
+		assert.Equal(t, "expected 1 to be in range (1, 3)", f.Details)
+
+		f = recoverFailure(t, func() { InRange(3, 1, 3, LowInclusive, "should panic") })
+		assert.Equal(t, "expected 3 to be in range [1, 3)", f.Details)
+
+		f = recoverFailure(t, func() { InRange(1, 1, 3, HighInclusive, "should panic") })
+		assert.Equal(t, "expected 1 to be in range (1, 3]", f.Details)
+
+		f = recoverFailure(t, func() { InRange(5, 1, 3, Inclusive, "should panic") })
+		assert.Equal(t, "expected 5 to be in range [1, 3]", f.Details)
+	})
+}
+
 // TestEmptyAssertions tests the Empty and NotEmpty functions
 func TestEmptyAssertions(t *testing.T) {
 
@@ -452,6 +524,30 @@ func TestEmptyAssertions(t *testing.T) {
 			Empty(42, "should panic")
 		})
 	})
+
+	// Test that any collection type is supported
+	t.Run("any collection type", func(t *testing.T) {
+		type names []string
+		list := []int{1}
+
+		NotEmpty([]int{1, 2, 3}, "should not panic")
+		NotEmpty(map[string]int{"a": 1}, "should not panic")
+		NotEmpty(names{"a"}, "should not panic")
+		NotEmpty([1]int{}, "should not panic")
+		NotEmpty(&list, "should not panic")
+		Empty([]string(nil), "should not panic")
+		Empty(make(chan int, 1), "should not panic")
+		Empty((*[]int)(nil), "should not panic")
+
+		f := recoverFailure(t, func() { NotEmpty([]int{}, "should panic") })
+		assert.Equal(t, "expected a non-empty slice, got empty", f.Details)
+
+		f = recoverFailure(t, func() { Empty(map[string]int{"a": 1, "b": 2}, "should panic") })
+		assert.Equal(t, "expected an empty map, got length 2", f.Details)
+
+		f = recoverFailure(t, func() { NotEmpty(42, "should panic") })
+		assert.Equal(t, "expected a map, slice, array, channel or string, got int", f.Details)
+	})
 }
 
 // TestContainsAssertions tests the Contains and NotContains functions
@@ -0,0 +1,38 @@
+package must
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// isNil reports whether value is nil: either a nil interface, or an interface holding a nil
+// pointer, map, slice, channel, func or unsafe pointer. Values of other kinds are never nil.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// describeNil describes a nil value for failure details, e.g. "nil" or "nil pointer of type *T".
+func describeNil(value any) string {
+	if value == nil {
+		return "nil"
+	}
+	t := reflect.TypeOf(value)
+	kind := t.Kind().String()
+	switch t.Kind() {
+	case reflect.Pointer:
+		kind = "pointer"
+	case reflect.Chan:
+		kind = "channel"
+	case reflect.UnsafePointer:
+		kind = "unsafe pointer"
+	}
+	return fmt.Sprintf("nil %s of type %T", kind, value)
+}
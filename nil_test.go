@@ -0,0 +1,63 @@
+package must
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsNilEngine tests that the nil assertions agree on every kind of nil value
+func TestIsNilEngine(t *testing.T) {
+
+	var (
+		ptr      *int
+		m        map[string]int
+		slice    []int
+		ch       chan int
+		fn       func()
+		unsafePt unsafe.Pointer
+		err      error
+		iface    any
+	)
+	nils := []any{ptr, m, slice, ch, fn, unsafePt, err, iface}
+
+	value := 0
+	nonNils := []any{
+		&value, map[string]int{}, []int{}, make(chan int), func() {}, unsafe.Pointer(&value),
+		errors.New("boom"), 0, "", struct{}{}, [0]int{}, false,
+	}
+
+	for _, v := range nils {
+		assert.True(t, isNil(v), "%T should be nil", v)
+		IsNil(v, "should not panic")
+		recoverFailure(t, func() { NotNil(v, "should panic") })
+		recoverFailure(t, func() { IsNotNil(v, "should panic") })
+	}
+	for _, v := range nonNils {
+		assert.False(t, isNil(v), "%T should not be nil", v)
+		NotNil(v, "should not panic")
+		IsNotNil(v, "should not panic")
+		recoverFailure(t, func() { IsNil(v, "should panic") })
+	}
+}
+
+// TestNilDetails tests the failure details of the nil assertions
+func TestNilDetails(t *testing.T) {
+
+	f := recoverFailure(t, func() { NotNil(nil, "should panic") })
+	assert.Equal(t, "expected a non-nil value, got nil", f.Details)
+
+	f = recoverFailure(t, func() { NotNil((*int)(nil), "should panic") })
+	assert.Equal(t, "expected a non-nil value, got nil pointer of type *int", f.Details)
+
+	f = recoverFailure(t, func() { IsNotNil([]string(nil), "should panic") })
+	assert.Equal(t, "expected non-nil, got nil slice of type []string", f.Details)
+
+	f = recoverFailure(t, func() { IsNotNil((chan int)(nil), "should panic") })
+	assert.Equal(t, "expected non-nil, got nil channel of type chan int", f.Details)
+
+	f = recoverFailure(t, func() { IsNil(42, "should panic") })
+	assert.Equal(t, "expected nil, got non-nil int", f.Details)
+}
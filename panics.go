@@ -0,0 +1,100 @@
+package must
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+)
+
+// Panics checks if fn panics and panics if it returns normally.
+// Assertions made by fn on the calling goroutine panic regardless of the failure policy, as with Try,
+// so a failed assertion inside fn counts as a panic.
+func Panics(fn func(), message string) {
+	if p := catchPanic(fn); p == nil {
+		testHelper().Helper()
+		abort(message, "expected function to panic, but it returned normally")
+	}
+}
+
+// NotPanics checks if fn returns normally and panics if it panics.
+// The failure details contain the recovered value and the stack of the panic.
+func NotPanics(fn func(), message string) {
+	if p := catchPanic(fn); p != nil {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected function not to panic, got %s", p), nil, p.value)
+	}
+}
+
+// PanicsWithValue checks if fn panics with a value deeply equal to expected and panics if it does not.
+// Values are compared like DeepEqual does, including their unexported fields.
+func PanicsWithValue(expected any, fn func(), message string) {
+	p := catchPanic(fn)
+	if p == nil {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected function to panic with %s, but it returned normally", describePanicValue(expected)), expected, nil)
+		return
+	}
+	// Unexported fields are always compared, a panic value either is the expected one or it is not
+	opts := currentDiffOptions()
+	opts.Unexported = true
+	if _, total := deepDiff(expected, p.value, opts); total > 0 {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected function to panic with %s, got %s", describePanicValue(expected), p), expected, p.value)
+	}
+}
+
+// PanicsWithError checks if fn panics with an error whose message equals expected and panics if it does not.
+// Failed assertions are errors too, their message is the one returned by Failure.Error.
+func PanicsWithError(expected string, fn func(), message string) {
+	p := catchPanic(fn)
+	if p == nil {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected function to panic with error %q, but it returned normally", expected), expected, nil)
+		return
+	}
+	if err, ok := p.value.(error); !ok || err.Error() != expected {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected function to panic with error %q, got %s", expected, p), expected, p.value)
+	}
+}
+
+// recovered is a panic recovered by catchPanic.
+type recovered struct {
+	value any
+	stack []byte
+}
+
+// String describes the panic for failure details, telling failed assertions apart from other panics.
+func (p *recovered) String() string {
+	if f, ok := p.value.(*Failure); ok {
+		return fmt.Sprintf("failed assertion %s: %s\npanic stack:\n%s", f.Assertion, f.Error(), p.stack)
+	}
+	return fmt.Sprintf("panic with %s\npanic stack:\n%s", describePanicValue(p.value), p.stack)
+}
+
+// catchPanic calls fn and returns the recovered panic, or nil if fn returned normally.
+func catchPanic(fn func()) (p *recovered) {
+	defer pushScope(&scope{policy: PolicyPanic})()
+	defer func() {
+		if p != nil {
+			p.value = recover()
+			p.stack = debug.Stack()
+		}
+	}()
+
+	// p is only cleared if fn returns, so panic(nil) is detected on older Go versions too
+	p = &recovered{}
+	fn()
+	return nil
+}
+
+// describePanicValue describes a panic value by its type and value.
+func describePanicValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case error:
+		return "error " + describeError(v)
+	}
+	return fmt.Sprintf("%v value %s", reflect.TypeOf(v), formatReflectValue(reflect.ValueOf(v)))
+}
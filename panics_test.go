@@ -0,0 +1,104 @@
+package must
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPanics tests the Panics and NotPanics functions
+func TestPanics(t *testing.T) {
+
+	// Success cases
+	Panics(func() { panic("boom") }, "should not panic")
+	Panics(func() { True(false, "guard") }, "should not panic")
+	NotPanics(func() {}, "should not panic")
+
+	// Failure cases
+	t.Run("Panics", func(t *testing.T) {
+		f := recoverFailure(t, func() { Panics(func() {}, "no panic") })
+		assert.Equal(t, "Panics", f.Assertion)
+		assert.Equal(t, "expected function to panic, but it returned normally", f.Details)
+	})
+
+	t.Run("NotPanics", func(t *testing.T) {
+		f := recoverFailure(t, func() { NotPanics(func() { panic("boom") }, "panicked") })
+		assert.Equal(t, "NotPanics", f.Assertion)
+		assert.Contains(t, f.Details, `expected function not to panic, got panic with string value "boom"`)
+		assert.Contains(t, f.Details, "panic stack:\n")
+		assert.Contains(t, f.Details, "TestPanics")
+		assert.Equal(t, "boom", f.Actual)
+	})
+
+	t.Run("NotPanics with failed assertion", func(t *testing.T) {
+		f := recoverFailure(t, func() { NotPanics(func() { Equal(1, 2, "inner") }, "outer") })
+		assert.Equal(t, "NotPanics", f.Assertion)
+		assert.Contains(t, f.Details, "expected function not to panic, got failed assertion Equal: inner: expected 1 to be equal to 2")
+
+		inner, ok := AsFailure(f.Actual)
+		assert.True(t, ok)
+		assert.Equal(t, "inner", inner.Message)
+	})
+
+	t.Run("panic policy is restored", func(t *testing.T) {
+		var failures []*Failure
+		buf := captureLog(t)
+		WithPolicy(PolicyLog, func() {
+			unregister := RegisterHandler(func(f *Failure) { failures = append(failures, f) })
+			defer unregister()
+
+			Panics(func() {}, "logged")
+		})
+		assert.Len(t, failures, 1)
+		assert.Contains(t, buf.String(), "expected function to panic, but it returned normally")
+	})
+}
+
+// TestPanicsWithValue tests the PanicsWithValue function
+func TestPanicsWithValue(t *testing.T) {
+
+	// Success cases
+	PanicsWithValue("boom", func() { panic("boom") }, "should not panic")
+	PanicsWithValue([]int{1, 2}, func() { panic([]int{1, 2}) }, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { PanicsWithValue(42, func() { panic(43) }, "wrong value") })
+	assert.Equal(t, "PanicsWithValue", f.Assertion)
+	assert.Contains(t, f.Details, "expected function to panic with int value 42, got panic with int value 43\npanic stack:")
+	assert.Equal(t, 42, f.Expected)
+	assert.Equal(t, 43, f.Actual)
+
+	f = recoverFailure(t, func() {
+		PanicsWithValue(errors.New("expected"), func() { panic(errors.New("other")) }, "wrong error")
+	})
+	assert.Contains(t, f.Details, `got panic with error *errors.errorString("other")`)
+
+	// Unexported fields are compared even when DeepEqual ignores them
+	prev := SetDiffOptions(DefaultDiffOptions)
+	defer SetDiffOptions(prev)
+	recoverFailure(t, func() {
+		PanicsWithValue(diffUser{token: "a"}, func() { panic(diffUser{token: "b"}) }, "wrong token")
+	})
+
+	f = recoverFailure(t, func() { PanicsWithValue(42, func() {}, "no panic") })
+	assert.Equal(t, "expected function to panic with int value 42, but it returned normally", f.Details)
+}
+
+// TestPanicsWithError tests the PanicsWithError function
+func TestPanicsWithError(t *testing.T) {
+
+	// Success cases
+	PanicsWithError("boom", func() { panic(errors.New("boom")) }, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { PanicsWithError("boom", func() { panic("boom") }, "not an error") })
+	assert.Equal(t, "PanicsWithError", f.Assertion)
+	assert.Contains(t, f.Details, `expected function to panic with error "boom", got panic with string value "boom"`)
+
+	f = recoverFailure(t, func() { PanicsWithError("boom", func() { panic(errors.New("bang")) }, "wrong error") })
+	assert.Contains(t, f.Details, `got panic with error *errors.errorString("bang")`)
+
+	f = recoverFailure(t, func() { PanicsWithError("boom", func() {}, "no panic") })
+	assert.Equal(t, `expected function to panic with error "boom", but it returned normally`, f.Details)
+}
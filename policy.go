@@ -0,0 +1,151 @@
+package must
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Policy defines what happens after the failure handlers of a failed assertion have been called.
+type Policy int
+
+const (
+	// PolicyPanic panics with the *Failure. This is the default.
+	PolicyPanic Policy = iota + 1
+	// PolicyExit logs the failure and exits the process with the configured exit code.
+	PolicyExit
+	// PolicyLog logs the failure and returns to the caller, letting the program continue.
+	PolicyLog
+	// PolicyGoexit logs the failure and terminates the failing goroutine with runtime.Goexit.
+	// Deferred calls run as usual. Calling it on the main goroutine stops main without exiting the program.
+	PolicyGoexit
+
+	// policyTest reports failures to a test, see WithT
+	policyTest Policy = -1
+)
+
+// PolicyEnv is the environment variable read at initialization to select the global policy.
+// Accepted values are "panic", "exit", "exit:<code>", "log" and "goexit".
+const PolicyEnv = "MUST_POLICY"
+
+// DefaultExitCode is the exit code used by PolicyExit unless changed with SetExitCode.
+const DefaultExitCode = 1
+
+var (
+	globalPolicy atomic.Int64
+	exitCode     atomic.Int64
+
+	// exit is replaced in tests
+	exit = os.Exit
+)
+
+func init() {
+	globalPolicy.Store(int64(PolicyPanic))
+	exitCode.Store(DefaultExitCode)
+	loadPolicyFromEnv()
+}
+
+// loadPolicyFromEnv applies the policy configured in the PolicyEnv environment variable, if any.
+func loadPolicyFromEnv() {
+	value, ok := os.LookupEnv(PolicyEnv)
+	if !ok || value == "" {
+		return
+	}
+
+	name, code, hasCode := strings.Cut(value, ":")
+	p, err := ParsePolicy(name)
+	if err != nil {
+		log.Printf("must: ignoring %s: %v", PolicyEnv, err)
+		return
+	}
+	if hasCode {
+		n, err := strconv.Atoi(code)
+		if err != nil || p != PolicyExit {
+			log.Printf("must: ignoring %s: invalid value %q", PolicyEnv, value)
+			return
+		}
+		SetExitCode(n)
+	}
+	SetPolicy(p)
+}
+
+// String returns the name of the policy as accepted by ParsePolicy.
+func (p Policy) String() string {
+	switch p {
+	case PolicyPanic:
+		return "panic"
+	case PolicyExit:
+		return "exit"
+	case PolicyLog:
+		return "log"
+	case PolicyGoexit:
+		return "goexit"
+	default:
+		return fmt.Sprintf("Policy(%d)", int(p))
+	}
+}
+
+// ParsePolicy returns the policy with the given name.
+func ParsePolicy(name string) (Policy, error) {
+	for _, p := range []Policy{PolicyPanic, PolicyExit, PolicyLog, PolicyGoexit} {
+		if strings.EqualFold(name, p.String()) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown policy %q", name)
+}
+
+// SetPolicy sets the process-wide failure policy and returns the previous one.
+// Policies installed with WithPolicy take precedence on their goroutine.
+func SetPolicy(p Policy) Policy {
+	validatePolicy(p)
+	return Policy(globalPolicy.Swap(int64(p)))
+}
+
+// SetExitCode sets the exit code used by PolicyExit.
+func SetExitCode(code int) {
+	exitCode.Store(int64(code))
+}
+
+// WithPolicy runs fn with the given failure policy applied to assertions made on the calling goroutine.
+// Goroutines started by fn use the process-wide policy.
+func WithPolicy(p Policy, fn func()) {
+	validatePolicy(p)
+	defer pushScope(&scope{policy: p})()
+	fn()
+}
+
+func validatePolicy(p Policy) {
+	if p < PolicyPanic || p > PolicyGoexit {
+		panic(fmt.Sprintf("must: invalid policy %v", p))
+	}
+}
+
+// enforce applies the policy in effect for the goroutine of f.
+func enforce(f *Failure) {
+	f.testT().Helper()
+
+	policy := Policy(globalPolicy.Load())
+	if f.scope != nil {
+		policy = f.scope.policy
+	}
+
+	switch policy {
+	case policyTest:
+		reportTest(f)
+	case PolicyExit:
+		log.Print("must: ", f.Error())
+		exit(int(exitCode.Load()))
+	case PolicyLog:
+		log.Print("must: ", f.Error())
+	case PolicyGoexit:
+		log.Print("must: ", f.Error())
+		runtime.Goexit()
+	default:
+		panic(f)
+	}
+}
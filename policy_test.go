@@ -0,0 +1,150 @@
+package must
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureLog redirects the standard logger into a buffer for the duration of the test
+func captureLog(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
+
+// TestParsePolicy tests parsing and printing of policy names
+func TestParsePolicy(t *testing.T) {
+
+	for _, p := range []Policy{PolicyPanic, PolicyExit, PolicyLog, PolicyGoexit} {
+		parsed, err := ParsePolicy(p.String())
+		require.NoError(t, err)
+		assert.Equal(t, p, parsed)
+	}
+
+	p, err := ParsePolicy("LOG")
+	require.NoError(t, err)
+	assert.Equal(t, PolicyLog, p)
+
+	_, err = ParsePolicy("ignore")
+	assert.Error(t, err)
+	assert.Equal(t, "Policy(42)", Policy(42).String())
+}
+
+// TestSetPolicy tests the process-wide policy
+func TestSetPolicy(t *testing.T) {
+
+	original := SetPolicy(PolicyLog)
+	defer SetPolicy(original)
+	assert.Equal(t, PolicyPanic, original)
+
+	t.Run("log", func(t *testing.T) {
+		out := captureLog(t)
+
+		// Log policy returns to the caller
+		Equal(1, 2, "soft failure")
+		assert.Contains(t, out.String(), "soft failure: expected 1 to be equal to 2")
+	})
+
+	t.Run("exit", func(t *testing.T) {
+		captureLog(t)
+		SetPolicy(PolicyExit)
+		defer SetExitCode(DefaultExitCode)
+
+		var code int
+		exit = func(c int) { code = c }
+		defer func() { exit = os.Exit }()
+
+		SetExitCode(3)
+		True(false, "fatal failure")
+		assert.Equal(t, 3, code)
+	})
+
+	t.Run("goexit", func(t *testing.T) {
+		captureLog(t)
+		SetPolicy(PolicyGoexit)
+
+		var reached, deferred bool
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { deferred = true }()
+			False(true, "worker failure")
+			reached = true
+		}()
+		wg.Wait()
+
+		assert.False(t, reached, "Expected the goroutine to stop at the failed assertion")
+		assert.True(t, deferred, "Expected deferred calls to run")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Panics(t, func() { SetPolicy(Policy(0)) })
+	})
+}
+
+// TestWithPolicy tests policies scoped to a goroutine
+func TestWithPolicy(t *testing.T) {
+	captureLog(t)
+
+	// Assertions inside the scope follow the scoped policy
+	WithPolicy(PolicyLog, func() {
+		NotEqual(1, 1, "should not panic")
+
+		// Other goroutines keep using the process-wide policy
+		var r any
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { r = recover() }()
+			NotEqual(1, 1, "should panic")
+		}()
+		wg.Wait()
+		assert.NotNil(t, r)
+
+		// Nested scopes take precedence
+		assert.Panics(t, func() {
+			WithPolicy(PolicyPanic, func() {
+				NotEqual(1, 1, "should panic")
+			})
+		})
+	})
+
+	// The scope is removed afterwards
+	assert.Panics(t, func() { NotEqual(1, 1, "should panic") })
+	assert.Empty(t, scopesOf(goroutineID()))
+}
+
+// TestPolicyFromEnv tests the policy selected with the environment variable
+func TestPolicyFromEnv(t *testing.T) {
+	captureLog(t)
+	original := SetPolicy(PolicyPanic)
+	defer SetPolicy(original)
+	defer SetExitCode(DefaultExitCode)
+
+	t.Setenv(PolicyEnv, "log")
+	loadPolicyFromEnv()
+	assert.Equal(t, PolicyLog, SetPolicy(PolicyPanic))
+
+	t.Setenv(PolicyEnv, "exit:7")
+	loadPolicyFromEnv()
+	assert.Equal(t, PolicyExit, SetPolicy(PolicyPanic))
+	assert.EqualValues(t, 7, exitCode.Load())
+
+	// Invalid values are ignored
+	t.Setenv(PolicyEnv, "log:7")
+	loadPolicyFromEnv()
+	assert.Equal(t, PolicyPanic, SetPolicy(PolicyPanic))
+
+	t.Setenv(PolicyEnv, "sometimes")
+	loadPolicyFromEnv()
+	assert.Equal(t, PolicyPanic, SetPolicy(PolicyPanic))
+}
@@ -0,0 +1,158 @@
+package must
+
+import "fmt"
+
+// PredicateOption configures the predicate assertions All, Any, None and their map counterparts.
+type PredicateOption func(*predicateOptions)
+
+type predicateOptions struct {
+	description string
+	reportAll   bool
+}
+
+// Describe names the predicate in the failure details, e.g. Describe("is positive").
+func Describe(description string) PredicateOption {
+	return func(o *predicateOptions) {
+		o.description = description
+	}
+}
+
+// ReportAll makes All, None, MapAll and MapNone report every violating entry instead of the first one,
+// limited by the MaxDifferences diff option. All and None then call the predicate for every element,
+// MapAll and MapNone always do so to report the entries in the order of their keys.
+func ReportAll() PredicateOption {
+	return func(o *predicateOptions) {
+		o.reportAll = true
+	}
+}
+
+// All checks if pred holds for every element of slice and panics if it does not.
+// The failure details contain the index and value of the first violating element.
+func All[T any](slice []T, pred func(T) bool, message string, opts ...PredicateOption) {
+	o := newPredicateOptions(opts)
+	var v violations
+	for i, e := range slice {
+		if !pred(e) && !v.add(o, fmt.Sprintf("[%d] = %s", i, formatValue(e))) {
+			break
+		}
+	}
+	if v.total > 0 {
+		testHelper().Helper()
+		v.report(message, o, "expected all elements to satisfy", "does not")
+	}
+}
+
+// Any checks if pred holds for at least one element of slice and panics if it does not.
+// An empty slice always fails.
+func Any[T any](slice []T, pred func(T) bool, message string, opts ...PredicateOption) {
+	for _, e := range slice {
+		if pred(e) {
+			return
+		}
+	}
+	o := newPredicateOptions(opts)
+	testHelper().Helper()
+	abort(message, fmt.Sprintf("expected any element to satisfy %s, none of %d elements does", o.name(), len(slice)))
+}
+
+// None checks if pred holds for no element of slice and panics if it does.
+// The failure details contain the index and value of the first element satisfying it.
+func None[T any](slice []T, pred func(T) bool, message string, opts ...PredicateOption) {
+	o := newPredicateOptions(opts)
+	var v violations
+	for i, e := range slice {
+		if pred(e) && !v.add(o, fmt.Sprintf("[%d] = %s", i, formatValue(e))) {
+			break
+		}
+	}
+	if v.total > 0 {
+		testHelper().Helper()
+		v.report(message, o, "expected no element to satisfy", "does")
+	}
+}
+
+// MapAll checks if pred holds for every entry of m and panics if it does not.
+// Violating entries are reported in the order of their formatted keys, so the report is deterministic.
+func MapAll[K comparable, V any](m map[K]V, pred func(K, V) bool, message string, opts ...PredicateOption) {
+	o := newPredicateOptions(opts)
+	var found []keyedDiff
+	for key, value := range m {
+		if !pred(key, value) {
+			found = append(found, keyedDiff{formatMapKey(key), fmt.Sprintf("[%s] = %s", formatValue(key), formatValue(value))})
+		}
+	}
+	v := violations{entries: sortDiffs(found), total: len(found)}
+	if v.total > 0 {
+		testHelper().Helper()
+		v.report(message, o, "expected all entries to satisfy", "does not")
+	}
+}
+
+// MapAny checks if pred holds for at least one entry of m and panics if it does not.
+// An empty map always fails.
+func MapAny[K comparable, V any](m map[K]V, pred func(K, V) bool, message string, opts ...PredicateOption) {
+	for key, value := range m {
+		if pred(key, value) {
+			return
+		}
+	}
+	o := newPredicateOptions(opts)
+	testHelper().Helper()
+	abort(message, fmt.Sprintf("expected any entry to satisfy %s, none of %d entries does", o.name(), len(m)))
+}
+
+// MapNone checks if pred holds for no entry of m and panics if it does.
+// Violating entries are reported in the order of their formatted keys, so the report is deterministic.
+func MapNone[K comparable, V any](m map[K]V, pred func(K, V) bool, message string, opts ...PredicateOption) {
+	o := newPredicateOptions(opts)
+	var found []keyedDiff
+	for key, value := range m {
+		if pred(key, value) {
+			found = append(found, keyedDiff{formatMapKey(key), fmt.Sprintf("[%s] = %s", formatValue(key), formatValue(value))})
+		}
+	}
+	v := violations{entries: sortDiffs(found), total: len(found)}
+	if v.total > 0 {
+		testHelper().Helper()
+		v.report(message, o, "expected no entry to satisfy", "does")
+	}
+}
+
+func newPredicateOptions(opts []PredicateOption) predicateOptions {
+	var o predicateOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// name returns the quoted description of the predicate.
+func (o predicateOptions) name() string {
+	if o.description == "" {
+		return "the predicate"
+	}
+	return quote(o.description)
+}
+
+// violations collects the entries violating a predicate assertion.
+type violations struct {
+	entries []string
+	total   int
+}
+
+// add records a violating entry and reports whether the remaining entries should be checked.
+func (v *violations) add(o predicateOptions, entry string) bool {
+	v.entries = appendLimited(v.entries, entry)
+	v.total++
+	return o.reportAll
+}
+
+// report reports a failure for the violating entries, there must be at least one.
+func (v *violations) report(message string, o predicateOptions, headline, verb string) {
+	testHelper().Helper()
+	if o.reportAll {
+		abort(message, formatList(headline+" "+o.name(), "violation", "violations", v.entries, v.total))
+	} else {
+		abort(message, fmt.Sprintf("%s %s, %s %s", headline, o.name(), v.entries[0], verb))
+	}
+}
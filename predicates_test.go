@@ -0,0 +1,110 @@
+package must
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func isEven(n int) bool { return n%2 == 0 }
+
+// TestAll tests the All function
+func TestAll(t *testing.T) {
+
+	// Success cases
+	All([]int{2, 4, 6}, isEven, "should not panic")
+	All([]int(nil), isEven, "should not panic")
+
+	// Failure cases
+	t.Run("first violation", func(t *testing.T) {
+		calls := 0
+		f := recoverFailure(t, func() {
+			All([]int{2, 3, 5}, func(n int) bool { calls++; return isEven(n) }, "odd numbers")
+		})
+		assert.Equal(t, "All", f.Assertion)
+		assert.Equal(t, "expected all elements to satisfy the predicate, [1] = 3 does not", f.Details)
+		assert.Equal(t, 2, calls, "should stop at the first violation")
+	})
+
+	t.Run("described", func(t *testing.T) {
+		f := recoverFailure(t, func() {
+			All([]string{"a", ""}, func(s string) bool { return s != "" }, "empty", Describe("is not empty"))
+		})
+		assert.Equal(t, `expected all elements to satisfy "is not empty", [1] = "" does not`, f.Details)
+	})
+
+	t.Run("report all", func(t *testing.T) {
+		f := recoverFailure(t, func() { All([]int{1, 2, 3}, isEven, "odd numbers", ReportAll(), Describe("is even")) })
+		assert.Equal(t, "expected all elements to satisfy \"is even\", found 2 violations:\n\t[0] = 1\n\t[2] = 3", f.Details)
+	})
+}
+
+// TestAny tests the Any function
+func TestAny(t *testing.T) {
+
+	// Success case
+	Any([]int{1, 2, 3}, isEven, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { Any([]int{1, 3}, isEven, "no even number", Describe("is even")) })
+	assert.Equal(t, "Any", f.Assertion)
+	assert.Equal(t, `expected any element to satisfy "is even", none of 2 elements does`, f.Details)
+
+	recoverFailure(t, func() { Any([]int{}, isEven, "empty") })
+}
+
+// TestNone tests the None function
+func TestNone(t *testing.T) {
+
+	// Success case
+	None([]int{1, 3}, isEven, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { None([]int{1, 2, 4}, isEven, "even number") })
+	assert.Equal(t, "None", f.Assertion)
+	assert.Equal(t, "expected no element to satisfy the predicate, [1] = 2 does", f.Details)
+
+	f = recoverFailure(t, func() { None([]int{1, 2, 4}, isEven, "even numbers", ReportAll()) })
+	assert.Equal(t, "expected no element to satisfy the predicate, found 2 violations:\n\t[1] = 2\n\t[2] = 4", f.Details)
+}
+
+// TestMapPredicates tests the MapAll, MapAny and MapNone functions
+func TestMapPredicates(t *testing.T) {
+
+	limits := map[string]int{"b": 20, "a": 10, "c": 30}
+	positive := func(_ string, v int) bool { return v > 0 }
+	large := func(_ string, v int) bool { return v >= 20 }
+
+	// Success cases
+	MapAll(limits, positive, "should not panic")
+	MapAny(limits, large, "should not panic")
+	MapNone(limits, func(_ string, v int) bool { return v > 100 }, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { MapAll(limits, large, "small limit") })
+	assert.Equal(t, "MapAll", f.Assertion)
+	assert.Equal(t, `expected all entries to satisfy the predicate, ["a"] = 10 does not`, f.Details)
+
+	f = recoverFailure(t, func() {
+		MapAny(limits, func(_ string, v int) bool { return v < 0 }, "none negative", Describe("is negative"))
+	})
+	assert.Equal(t, `expected any entry to satisfy "is negative", none of 3 entries does`, f.Details)
+
+	f = recoverFailure(t, func() { MapNone(limits, large, "large limits", ReportAll()) })
+	assert.Equal(t, "MapNone", f.Assertion)
+	assert.Equal(t, "expected no entry to satisfy the predicate, found 2 violations:\n\t[\"b\"] = 20\n\t[\"c\"] = 30", f.Details)
+
+	t.Run("keys that print the same", func(t *testing.T) {
+		mixed := map[any]int{1: 1, int64(1): -1, nil: 2}
+
+		var calls int
+		MapNone(mixed, func(any, int) bool { calls++; return false }, "should not panic")
+		assert.Equal(t, 3, calls)
+
+		f := recoverFailure(t, func() { MapAll(mixed, func(_ any, v int) bool { return v > 0 }, "negative", ReportAll()) })
+		assert.Equal(t, "expected all entries to satisfy the predicate, found 1 violation:\n\t[1] = -1", f.Details)
+
+		f = recoverFailure(t, func() { MapNone(mixed, func(k any, _ int) bool { return k == nil }, "nil key") })
+		assert.Equal(t, "expected no entry to satisfy the predicate, [<nil>] = 2 does", f.Details)
+	})
+}
@@ -0,0 +1,42 @@
+package must
+
+// Catch recovers from a panic caused by a failed assertion and stores the *Failure in *err.
+// It must be called directly by a deferred statement, typically at the boundary of an exported API:
+//
+//	func (s *Store) Put(key string, value []byte) (err error) {
+//		defer must.Catch(&err)
+//		...
+//	}
+//
+// Panics that did not originate from a failed assertion are re-raised.
+// Catch only sees failures that panic; use Try to force the panic policy for a block.
+func Catch(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	f, ok := r.(*Failure)
+	if !ok {
+		panic(r)
+	}
+	*err = f
+}
+
+// Try calls fn and returns its result, or the zero value and the *Failure if an assertion made by fn fails.
+// Assertions made by fn on the calling goroutine panic regardless of the failure policy, so they can be recovered;
+// failure handlers are still called. Panics that did not originate from a failed assertion are re-raised.
+func Try[T any](fn func() T) (value T, err error) {
+	defer pushScope(&scope{policy: PolicyPanic})()
+	defer Catch(&err)
+
+	return fn(), nil
+}
+
+// TryDo is like Try for functions that do not return a value.
+func TryDo(fn func()) (err error) {
+	defer pushScope(&scope{policy: PolicyPanic})()
+	defer Catch(&err)
+
+	fn()
+	return nil
+}
@@ -0,0 +1,74 @@
+package must
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// catching converts failures of fn into an error with Catch
+func catching(fn func()) (err error) {
+	defer Catch(&err)
+	fn()
+	return nil
+}
+
+// TestCatch tests recovering failures in a deferred call
+func TestCatch(t *testing.T) {
+
+	// Success case
+	assert.NoError(t, catching(func() { True(true, "passes") }))
+
+	// Failure case
+	err := catching(func() { Equal("a", "b", "strings differ") })
+	require.Error(t, err)
+	var f *Failure
+	require.True(t, errors.As(err, &f))
+	assert.Equal(t, "strings differ", f.Message)
+
+	// Foreign panics are re-raised
+	assert.PanicsWithValue(t, "foreign", func() {
+		_ = catching(func() { panic("foreign") })
+	})
+}
+
+// TestTry tests converting failures of a function into an error
+func TestTry(t *testing.T) {
+
+	// Success case
+	value, err := Try(func() int { return 42 })
+	require.NoError(t, err)
+	assert.Equal(t, 42, value)
+	require.NoError(t, TryDo(func() {}))
+
+	// Failure case
+	value, err = Try(func() int {
+		NotZero(0, "zero")
+		return 42
+	})
+	assert.Zero(t, value)
+	f, ok := AsFailure(err)
+	require.True(t, ok)
+	assert.Equal(t, "NotZero", f.Assertion)
+
+	err = TryDo(func() { MapHas(map[string]int{}, "key", "missing key") })
+	assert.ErrorContains(t, err, "missing key")
+
+	// Failures are recovered even when the policy would not panic
+	captureLog(t)
+	WithPolicy(PolicyLog, func() {
+		_, err := Try(func() bool {
+			True(false, "should be caught")
+			return true
+		})
+		assert.Error(t, err)
+	})
+
+	// Foreign panics are re-raised
+	assert.PanicsWithError(t, "foreign", func() {
+		_ = TryDo(func() { panic(errors.New("foreign")) })
+	})
+	assert.Empty(t, scopesOf(goroutineID()))
+}
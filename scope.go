@@ -0,0 +1,73 @@
+package must
+
+import "sync"
+
+// scope holds settings that apply to assertions made on a single goroutine.
+// Scopes are stacked, the innermost scope that sets a value wins.
+type scope struct {
+	policy Policy // Failure policy, zero to inherit it
+	t      TB     // Test failures are reported to, set with policyTest
+	fatal  bool   // Whether failures stop the test
+
+	handlers  []FailureHandler // Handlers called in addition to the registered ones
+	inHandler bool             // Set while a failure handler runs on the goroutine
+	checks    int              // Number of Check calls the scope was installed in
+}
+
+var (
+	scopes      = map[uint64][]*scope{}
+	scopesMutex sync.Mutex
+)
+
+// pushScope installs s on the current goroutine and returns a function that removes it.
+// The returned function may be called from any goroutine.
+func pushScope(s *scope) (pop func()) {
+	gid := goroutineID()
+	if activeChecks.Load() > 0 {
+		_, s.checks = checkFrames()
+	}
+
+	scopesMutex.Lock()
+	scopes[gid] = append(scopes[gid], s)
+	scopesMutex.Unlock()
+
+	return func() {
+		scopesMutex.Lock()
+		defer scopesMutex.Unlock()
+
+		stack := scopes[gid]
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i] == s {
+				stack = append(stack[:i:i], stack[i+1:]...)
+				break
+			}
+		}
+		if len(stack) == 0 {
+			delete(scopes, gid)
+		} else {
+			scopes[gid] = stack
+		}
+	}
+}
+
+// scopesOf returns a copy of the scope stack of the given goroutine, innermost last.
+func scopesOf(gid uint64) []*scope {
+	scopesMutex.Lock()
+	defer scopesMutex.Unlock()
+
+	if len(scopes[gid]) == 0 {
+		return nil
+	}
+	return append([]*scope(nil), scopes[gid]...)
+}
+
+// policyScope returns the innermost scope of the given goroutine that sets a policy, or nil.
+func policyScope(gid uint64) *scope {
+	stack := scopesOf(gid)
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].policy != 0 {
+			return stack[i]
+		}
+	}
+	return nil
+}
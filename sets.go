@@ -0,0 +1,281 @@
+package must
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// The set assertions compare slices regardless of element order. Each comes in a variant for comparable elements
+// and a Func variant taking an equality function, which should be an equivalence relation.
+// The failure details list the missing, extra, shared or duplicated elements.
+
+// ElementsMatch checks if value has the same elements as expected, with the same number of repetitions
+// but in any order, and panics if it does not.
+func ElementsMatch[T comparable](expected, value []T, message string) {
+	counts := make(map[T]int, len(expected))
+	for _, e := range expected {
+		counts[e]++
+	}
+	var extra []T
+	for _, v := range value {
+		if counts[v] > 0 {
+			counts[v]--
+		} else {
+			extra = append(extra, v)
+		}
+	}
+	var missing []T
+	for _, e := range expected {
+		for ; counts[e] > 0; counts[e]-- {
+			missing = append(missing, e)
+		}
+	}
+	if len(missing) > 0 || len(extra) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatSetDifferences("expected elements to match", missing, extra), expected, value)
+	}
+}
+
+// ElementsMatchFunc is like ElementsMatch with elements compared by eq.
+func ElementsMatchFunc[T any](expected, value []T, eq func(a, b T) bool, message string) {
+	matched := make([]bool, len(expected))
+	var extra []T
+	for _, v := range value {
+		found := false
+		for i, e := range expected {
+			if !matched[i] && eq(e, v) {
+				matched[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			extra = append(extra, v)
+		}
+	}
+	var missing []T
+	for i, e := range expected {
+		if !matched[i] {
+			missing = append(missing, e)
+		}
+	}
+	if len(missing) > 0 || len(extra) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatSetDifferences("expected elements to match", missing, extra), expected, value)
+	}
+}
+
+// SubsetOf checks if every element of value is an element of set and panics if not.
+func SubsetOf[T comparable](value, set []T, message string) {
+	if missing := notIn(value, set); len(missing) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatSetDifferences("expected a subset", nil, missing), set, value)
+	}
+}
+
+// SubsetOfFunc is like SubsetOf with elements compared by eq.
+func SubsetOfFunc[T any](value, set []T, eq func(a, b T) bool, message string) {
+	if missing := notInFunc(value, set, eq); len(missing) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatSetDifferences("expected a subset", nil, missing), set, value)
+	}
+}
+
+// SupersetOf checks if every element of subset is an element of value and panics if not.
+func SupersetOf[T comparable](value, subset []T, message string) {
+	if missing := notIn(subset, value); len(missing) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatSetDifferences("expected a superset", missing, nil), subset, value)
+	}
+}
+
+// SupersetOfFunc is like SupersetOf with elements compared by eq.
+func SupersetOfFunc[T any](value, subset []T, eq func(a, b T) bool, message string) {
+	if missing := notInFunc(subset, value, eq); len(missing) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatSetDifferences("expected a superset", missing, nil), subset, value)
+	}
+}
+
+// Disjoint checks if a and b have no element in common and panics if they do.
+func Disjoint[T comparable](a, b []T, message string) {
+	set := make(map[T]bool, len(b))
+	for _, e := range b {
+		set[e] = true
+	}
+	var shared []T
+	for _, e := range distinct(a) {
+		if set[e] {
+			shared = append(shared, e)
+		}
+	}
+	if len(shared) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatShared(shared), a, b)
+	}
+}
+
+// DisjointFunc is like Disjoint with elements compared by eq.
+func DisjointFunc[T any](a, b []T, eq func(a, b T) bool, message string) {
+	var shared []T
+	for _, e := range distinctFunc(a, eq) {
+		if indexFunc(b, e, eq) >= 0 {
+			shared = append(shared, e)
+		}
+	}
+	if len(shared) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatShared(shared), a, b)
+	}
+}
+
+// SetEqual checks if value and expected have the same elements, ignoring order and repetitions, and panics if not.
+func SetEqual[T comparable](expected, value []T, message string) {
+	missing, extra := notIn(expected, value), notIn(value, expected)
+	if len(missing) > 0 || len(extra) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatSetDifferences("expected sets to be equal", missing, extra), expected, value)
+	}
+}
+
+// SetEqualFunc is like SetEqual with elements compared by eq.
+func SetEqualFunc[T any](expected, value []T, eq func(a, b T) bool, message string) {
+	missing, extra := notInFunc(expected, value, eq), notInFunc(value, expected, eq)
+	if len(missing) > 0 || len(extra) > 0 {
+		testHelper().Helper()
+		abortValues(message, formatSetDifferences("expected sets to be equal", missing, extra), expected, value)
+	}
+}
+
+// Unique checks if value has no duplicate elements and panics if it does.
+// The failure details list each duplicated value with the indices it occurs at.
+func Unique[T comparable](value []T, message string) {
+	indices := make(map[T][]int, len(value))
+	for i, v := range value {
+		indices[v] = append(indices[v], i)
+	}
+	var duplicates []string
+	total := 0
+	for i, v := range value {
+		if at := indices[v]; len(at) > 1 && at[0] == i {
+			duplicates = appendLimited(duplicates, fmt.Sprintf("%s at indices %v", formatValue(v), at))
+			total++
+		}
+	}
+	if total > 0 {
+		testHelper().Helper()
+		abortValues(message, formatList("expected unique elements", "duplicate", "duplicates", duplicates, total), nil, value)
+	}
+}
+
+// UniqueFunc is like Unique with elements compared by eq.
+func UniqueFunc[T any](value []T, eq func(a, b T) bool, message string) {
+	seen := make([]bool, len(value))
+	var duplicates []string
+	total := 0
+	for i, v := range value {
+		if seen[i] {
+			continue
+		}
+		at := []int{i}
+		for j := i + 1; j < len(value); j++ {
+			if !seen[j] && eq(v, value[j]) {
+				seen[j] = true
+				at = append(at, j)
+			}
+		}
+		if len(at) > 1 {
+			duplicates = appendLimited(duplicates, fmt.Sprintf("%s at indices %v", formatValue(v), at))
+			total++
+		}
+	}
+	if total > 0 {
+		testHelper().Helper()
+		abortValues(message, formatList("expected unique elements", "duplicate", "duplicates", duplicates, total), nil, value)
+	}
+}
+
+// notIn returns the distinct elements of a that are not in b, in the order of a.
+func notIn[T comparable](a, b []T) []T {
+	set := make(map[T]bool, len(b))
+	for _, e := range b {
+		set[e] = true
+	}
+	var result []T
+	for _, e := range distinct(a) {
+		if !set[e] {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// notInFunc is like notIn with elements compared by eq.
+func notInFunc[T any](a, b []T, eq func(a, b T) bool) []T {
+	var result []T
+	for _, e := range distinctFunc(a, eq) {
+		if indexFunc(b, e, eq) < 0 {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// distinct returns the elements of s without repetitions, keeping the first occurrence.
+func distinct[T comparable](s []T) []T {
+	seen := make(map[T]bool, len(s))
+	var result []T
+	for _, e := range s {
+		if !seen[e] {
+			seen[e] = true
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// distinctFunc is like distinct with elements compared by eq.
+func distinctFunc[T any](s []T, eq func(a, b T) bool) []T {
+	var result []T
+	for _, e := range s {
+		if indexFunc(result, e, eq) < 0 {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// indexFunc returns the index of the first element of s equal to v according to eq, or -1.
+func indexFunc[T any](s []T, v T, eq func(a, b T) bool) int {
+	for i, e := range s {
+		if eq(e, v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// formatSetDifferences lists missing and extra elements under a headline.
+func formatSetDifferences[T any](headline string, missing, extra []T) string {
+	var diffs []string
+	for _, e := range missing {
+		diffs = appendLimited(diffs, "missing "+formatValue(e))
+	}
+	for _, e := range extra {
+		diffs = appendLimited(diffs, "extra "+formatValue(e))
+	}
+	return formatDifferences(headline, diffs, len(missing)+len(extra))
+}
+
+// formatShared lists the elements shared by two slices.
+func formatShared[T any](elements []T) string {
+	var items []string
+	for _, e := range elements {
+		items = appendLimited(items, formatValue(e))
+	}
+	return formatList("expected no common elements", "shared element", "shared elements", items, len(elements))
+}
+
+// formatValue formats a value for failure details, quoting strings.
+func formatValue(v any) string {
+	return formatReflectValue(reflect.ValueOf(v))
+}
@@ -0,0 +1,118 @@
+package must
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sameLength compares strings by their length
+func sameLength(a, b string) bool { return len(a) == len(b) }
+
+// TestElementsMatch tests the ElementsMatch and ElementsMatchFunc functions
+func TestElementsMatch(t *testing.T) {
+
+	// Success cases
+	ElementsMatch([]int{1, 2, 2, 3}, []int{2, 3, 1, 2}, "should not panic")
+	ElementsMatch([]string{}, nil, "should not panic")
+	ElementsMatchFunc([]string{"a", "bb"}, []string{"cc", "d"}, sameLength, "should not panic")
+
+	// Failure cases
+	t.Run("ElementsMatch", func(t *testing.T) {
+		f := recoverFailure(t, func() { ElementsMatch([]int{1, 2, 2, 3}, []int{1, 2, 4}, "mismatch") })
+		assert.Equal(t, "ElementsMatch", f.Assertion)
+		assert.Equal(t, "expected elements to match, found 3 differences:\n\tmissing 2\n\tmissing 3\n\textra 4", f.Details)
+		assert.Equal(t, []int{1, 2, 2, 3}, f.Expected)
+	})
+
+	t.Run("ElementsMatchFunc", func(t *testing.T) {
+		f := recoverFailure(t, func() { ElementsMatchFunc([]string{"a", "bb"}, []string{"c", "d"}, sameLength, "mismatch") })
+		assert.Equal(t, "ElementsMatchFunc", f.Assertion)
+		assert.Equal(t, "expected elements to match, found 2 differences:\n\tmissing \"bb\"\n\textra \"d\"", f.Details)
+	})
+}
+
+// TestSubsetAssertions tests the SubsetOf and SupersetOf functions
+func TestSubsetAssertions(t *testing.T) {
+
+	// Success cases
+	SubsetOf([]int{1, 1, 3}, []int{1, 2, 3}, "should not panic")
+	SubsetOf(nil, []int{1}, "should not panic")
+	SupersetOf([]int{1, 2, 3}, []int{3, 1}, "should not panic")
+	SubsetOfFunc([]string{"x"}, []string{"a", "bb"}, sameLength, "should not panic")
+	SupersetOfFunc([]string{"a", "bb"}, []string{"cc"}, sameLength, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { SubsetOf([]int{1, 4, 5, 4}, []int{1, 2, 3}, "not a subset") })
+	assert.Equal(t, "SubsetOf", f.Assertion)
+	assert.Equal(t, "expected a subset, found 2 differences:\n\textra 4\n\textra 5", f.Details)
+
+	f = recoverFailure(t, func() { SupersetOf([]string{"a"}, []string{"a", "b"}, "not a superset") })
+	assert.Equal(t, "SupersetOf", f.Assertion)
+	assert.Equal(t, "expected a superset, found 1 difference:\n\tmissing \"b\"", f.Details)
+
+	f = recoverFailure(t, func() { SubsetOfFunc([]string{"ccc"}, []string{"a"}, sameLength, "not a subset") })
+	assert.Equal(t, "expected a subset, found 1 difference:\n\textra \"ccc\"", f.Details)
+
+	f = recoverFailure(t, func() { SupersetOfFunc([]string{"a"}, []string{"ccc"}, sameLength, "not a superset") })
+	assert.Equal(t, "expected a superset, found 1 difference:\n\tmissing \"ccc\"", f.Details)
+}
+
+// TestDisjoint tests the Disjoint and DisjointFunc functions
+func TestDisjoint(t *testing.T) {
+
+	// Success cases
+	Disjoint([]int{1, 2}, []int{3, 4}, "should not panic")
+	DisjointFunc([]string{"a"}, []string{"bb"}, sameLength, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { Disjoint([]int{1, 2, 2, 3}, []int{2, 3}, "overlap") })
+	assert.Equal(t, "Disjoint", f.Assertion)
+	assert.Equal(t, "expected no common elements, found 2 shared elements:\n\t2\n\t3", f.Details)
+
+	f = recoverFailure(t, func() { DisjointFunc([]string{"a"}, []string{"b"}, sameLength, "overlap") })
+	assert.Equal(t, "expected no common elements, found 1 shared element:\n\t\"a\"", f.Details)
+}
+
+// TestSetEqual tests the SetEqual and SetEqualFunc functions
+func TestSetEqual(t *testing.T) {
+
+	// Success cases
+	SetEqual([]int{1, 2, 2}, []int{2, 1, 1}, "should not panic")
+	SetEqualFunc([]string{"a", "b"}, []string{"c"}, sameLength, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { SetEqual([]int{1, 2}, []int{2, 3, 3}, "different") })
+	assert.Equal(t, "SetEqual", f.Assertion)
+	assert.Equal(t, "expected sets to be equal, found 2 differences:\n\tmissing 1\n\textra 3", f.Details)
+
+	f = recoverFailure(t, func() { SetEqualFunc([]string{"a"}, []string{"bb"}, sameLength, "different") })
+	assert.Equal(t, "expected sets to be equal, found 2 differences:\n\tmissing \"a\"\n\textra \"bb\"", f.Details)
+}
+
+// TestUnique tests the Unique and UniqueFunc functions
+func TestUnique(t *testing.T) {
+
+	// Success cases
+	Unique([]int{1, 2, 3}, "should not panic")
+	Unique([]string(nil), "should not panic")
+	UniqueFunc([]string{"a", "bb"}, sameLength, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { Unique([]string{"a", "b", "a", "c", "b", "a"}, "duplicates") })
+	assert.Equal(t, "Unique", f.Assertion)
+	assert.Equal(t, "expected unique elements, found 2 duplicates:\n\t\"a\" at indices [0 2 5]\n\t\"b\" at indices [1 4]", f.Details)
+
+	f = recoverFailure(t, func() { UniqueFunc([]string{"a", "bb", "c"}, sameLength, "duplicates") })
+	assert.Equal(t, "UniqueFunc", f.Assertion)
+	assert.Equal(t, "expected unique elements, found 1 duplicate:\n\t\"a\" at indices [0 2]", f.Details)
+
+	t.Run("limited", func(t *testing.T) {
+		prev := SetDiffOptions(DiffOptions{MaxDifferences: 1})
+		defer SetDiffOptions(prev)
+
+		f := recoverFailure(t, func() { Unique([]int{1, 1, 2, 2, 3, 3}, "duplicates") })
+		assert.True(t, strings.HasSuffix(f.Details, "\n\t1 at indices [0 1]\n\t... and 2 more"), f.Details)
+	})
+}
@@ -0,0 +1,107 @@
+package must
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The string assertions quote their inputs in the failure details, escaping invalid UTF-8 and control characters,
+// and shorten them to the MaxLineLength diff option, so that arbitrary data cannot corrupt log output.
+
+// HasPrefix checks if value starts with prefix and panics if it does not.
+func HasPrefix(value, prefix string, message string) {
+	if !strings.HasPrefix(value, prefix) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to have prefix %s", quote(value), quote(prefix)), prefix, value)
+	}
+}
+
+// HasSuffix checks if value ends with suffix and panics if it does not.
+func HasSuffix(value, suffix string, message string) {
+	if !strings.HasSuffix(value, suffix) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to have suffix %s", quote(value), quote(suffix)), suffix, value)
+	}
+}
+
+// StringContains checks if value contains substring and panics if it does not.
+func StringContains(value, substring string, message string) {
+	if !strings.Contains(value, substring) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to contain %s", quote(value), quote(substring)), substring, value)
+	}
+}
+
+// Matches checks if value matches the regular expression pattern and panics if it does not.
+// Recently used patterns are kept compiled, an invalid pattern is reported as a failure.
+func Matches(value, pattern string, message string) {
+	re, err := compilePattern(pattern)
+	if err != nil {
+		testHelper().Helper()
+		abort(message, fmt.Sprintf("invalid pattern %q: %v", pattern, err))
+		return
+	}
+	if !re.MatchString(value) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to match %q", quote(value), pattern), pattern, value)
+	}
+}
+
+// ValidUTF8 checks if value is valid UTF-8 and panics if it is not.
+func ValidUTF8(value string, message string) {
+	for i, r := range value {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(value[i:]); size == 1 {
+				testHelper().Helper()
+				abort(message, fmt.Sprintf("expected valid UTF-8, found invalid byte %#02x at offset %d in %s", value[i], i, quote(value)))
+				return
+			}
+		}
+	}
+}
+
+// EqualFold checks if value is equal to expected under Unicode case folding and panics if it is not.
+func EqualFold(expected, value string, message string) {
+	if !strings.EqualFold(expected, value) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to equal %s ignoring case", quote(value), quote(expected)), expected, value)
+	}
+}
+
+// NoControlChars checks if value contains no control characters, including tabs and newlines, and panics if it does.
+func NoControlChars(value string, message string) {
+	for i, r := range value {
+		if unicode.IsControl(r) {
+			testHelper().Helper()
+			abort(message, fmt.Sprintf("expected no control characters, found %U at offset %d in %s", r, i, quote(value)))
+			return
+		}
+	}
+}
+
+// MaxRunes checks if value has at most n characters (runes) and panics if it has more.
+func MaxRunes(value string, n int, message string) {
+	if count := utf8.RuneCountInString(value); count > n {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected at most %d characters, got %d in %s", n, count, quote(value)), n, count)
+	}
+}
+
+// quote quotes s with Go escapes and shortens it to the MaxLineLength diff option.
+// Unlike truncateRunes it keeps invalid UTF-8 bytes, which are escaped.
+func quote(s string) string {
+	limit := currentDiffOptions().MaxLineLength
+	if limit <= 0 || utf8.RuneCountInString(s) <= limit {
+		return strconv.Quote(s)
+	}
+	end, count := 0, 0
+	for count < limit {
+		_, size := utf8.DecodeRuneInString(s[end:])
+		end += size
+		count++
+	}
+	return strconv.Quote(s[:end]) + fmt.Sprintf("… (%d more characters)", utf8.RuneCountInString(s[end:]))
+}
@@ -0,0 +1,130 @@
+package must
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStringAssertions tests the HasPrefix, HasSuffix, StringContains and EqualFold functions
+func TestStringAssertions(t *testing.T) {
+
+	// Success cases
+	HasPrefix("https://example.com", "https://", "should not panic")
+	HasSuffix("config.yaml", ".yaml", "should not panic")
+	StringContains("hello world", "o w", "should not panic")
+	EqualFold("Ünïcode", "üNÏCODE", "should not panic")
+	EqualFold("Go", "GO", "should not panic")
+
+	// Failure cases
+	t.Run("HasPrefix", func(t *testing.T) {
+		f := recoverFailure(t, func() { HasPrefix("http://example.com", "https://", "insecure") })
+		assert.Equal(t, "HasPrefix", f.Assertion)
+		assert.Equal(t, `expected "http://example.com" to have prefix "https://"`, f.Details)
+		assert.Equal(t, "https://", f.Expected)
+	})
+
+	t.Run("HasSuffix", func(t *testing.T) {
+		f := recoverFailure(t, func() { HasSuffix("config.json", ".yaml", "wrong format") })
+		assert.Equal(t, `expected "config.json" to have suffix ".yaml"`, f.Details)
+	})
+
+	t.Run("StringContains", func(t *testing.T) {
+		f := recoverFailure(t, func() { StringContains("hello", "bye", "missing") })
+		assert.Equal(t, `expected "hello" to contain "bye"`, f.Details)
+	})
+
+	t.Run("EqualFold", func(t *testing.T) {
+		f := recoverFailure(t, func() { EqualFold("Go", "Rust", "different") })
+		assert.Equal(t, `expected "Rust" to equal "Go" ignoring case`, f.Details)
+	})
+}
+
+// TestMatches tests the Matches function and the pattern cache
+func TestMatches(t *testing.T) {
+
+	// Success case
+	Matches("v1.2.3", `^v\d+\.\d+\.\d+$`, "should not panic")
+	re, err := compilePattern(`^v\d+\.\d+\.\d+$`)
+	assert.NoError(t, err)
+	cached, _ := compilePattern(`^v\d+\.\d+\.\d+$`)
+	assert.Same(t, re, cached)
+
+	// The cache is bounded, the least recently used patterns are evicted
+	for i := range maxPatterns {
+		compilePattern(fmt.Sprintf("^runtime-%d$", i))
+		if i == maxPatterns/2 {
+			compilePattern(`^v\d+\.\d+\.\d+$`)
+		}
+	}
+	assert.Len(t, patterns.entries, maxPatterns)
+	assert.NotContains(t, patterns.entries, "^runtime-0$")
+	assert.Contains(t, patterns.entries, `^v\d+\.\d+\.\d+$`)
+	evicted, _ := compilePattern(`^runtime-0$`)
+	assert.True(t, evicted.MatchString("runtime-0"))
+
+	// Failure cases
+	f := recoverFailure(t, func() { Matches("1.2.3", `^v\d+`, "not a version") })
+	assert.Equal(t, "Matches", f.Assertion)
+	assert.Equal(t, `expected "1.2.3" to match "^v\\d+"`, f.Details)
+
+	f = recoverFailure(t, func() { Matches("x", `[`, "invalid") })
+	assert.Contains(t, f.Details, `invalid pattern "[": error parsing regexp`)
+}
+
+// TestValidUTF8 tests the ValidUTF8 function
+func TestValidUTF8(t *testing.T) {
+
+	// Success cases
+	ValidUTF8("héllo, 世界", "should not panic")
+	ValidUTF8("replacement � character", "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() { ValidUTF8("ab\xffc", "binary") })
+	assert.Equal(t, "ValidUTF8", f.Assertion)
+	assert.Equal(t, `expected valid UTF-8, found invalid byte 0xff at offset 2 in "ab\xffc"`, f.Details)
+}
+
+// TestNoControlChars tests the NoControlChars function
+func TestNoControlChars(t *testing.T) {
+
+	// Success case
+	NoControlChars("plain text, ünïcode", "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { NoControlChars("bell\a", "control") })
+	assert.Equal(t, "NoControlChars", f.Assertion)
+	assert.Equal(t, `expected no control characters, found U+0007 at offset 4 in "bell\a"`, f.Details)
+
+	f = recoverFailure(t, func() { NoControlChars("a\x1b[31mred", "escape") })
+	assert.Contains(t, f.Details, `found U+001B at offset 1 in "a\x1b[31mred"`)
+}
+
+// TestMaxRunes tests the MaxRunes function
+func TestMaxRunes(t *testing.T) {
+
+	// Success case
+	MaxRunes("世界", 2, "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() { MaxRunes("世界!", 2, "too long") })
+	assert.Equal(t, "MaxRunes", f.Assertion)
+	assert.Equal(t, `expected at most 2 characters, got 3 in "世界!"`, f.Details)
+	assert.Equal(t, 2, f.Expected)
+	assert.Equal(t, 3, f.Actual)
+}
+
+// TestQuote tests that quoted inputs are escaped and truncated
+func TestQuote(t *testing.T) {
+
+	assert.Equal(t, `"a\n\xff"`, quote("a\n\xff"))
+
+	prev := SetDiffOptions(DiffOptions{MaxLineLength: 3})
+	defer SetDiffOptions(prev)
+
+	assert.Equal(t, `"ab\xff"… (2 more characters)`, quote("ab\xffcd"))
+	f := recoverFailure(t, func() { HasPrefix(strings.Repeat("x", 10), "y", "long") })
+	assert.Equal(t, `expected "xxx"… (7 more characters) to have prefix "y"`, f.Details)
+}
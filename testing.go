@@ -0,0 +1,69 @@
+package must
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// TB is the subset of testing.TB used to report failed assertions to a test.
+// *testing.T, *testing.B and *testing.F satisfy it.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+	Cleanup(func())
+}
+
+// WithT reports failed assertions made on the calling goroutine to t with t.Fatalf instead of panicking,
+// until the test finishes. It is safe to use in parallel tests, each installing its own scope.
+// Assertions made on goroutines started by the test use the process-wide policy.
+// Reports are attributed to the line that called the failed assertion.
+func WithT(t TB) {
+	t.Helper()
+	withT(t, true)
+}
+
+// WithTErrorf is like WithT, but reports failed assertions with t.Errorf and lets the test continue.
+func WithTErrorf(t TB) {
+	t.Helper()
+	withT(t, false)
+}
+
+// testScopes counts the installed test scopes, so that testHelper can skip looking them up when there are none.
+var testScopes atomic.Int64
+
+func withT(t TB, fatal bool) {
+	testScopes.Add(1)
+	pop := pushScope(&scope{policy: policyTest, t: t, fatal: fatal})
+	t.Cleanup(func() {
+		pop()
+		testScopes.Add(-1)
+	})
+}
+
+// testHelper returns the test that failures on the calling goroutine are reported to, or a no-op.
+// Every function on the path from an exported assertion to abort calls testHelper().Helper()
+// on its failure branch, so the test output points at the caller of the assertion instead of this library.
+// Checks shared by several assertions return a *problem instead of failing themselves.
+func testHelper() interface{ Helper() } {
+	if testScopes.Load() == 0 {
+		return noHelper{}
+	}
+	if s := policyScope(goroutineID()); s != nil && s.t != nil {
+		return s.t
+	}
+	return noHelper{}
+}
+
+// reportTest reports f to the test of its scope.
+func reportTest(f *Failure) {
+	t := f.scope.t
+	t.Helper()
+
+	report := fmt.Sprintf("%s: %s: %s", f.Assertion, f.Message, f.Details)
+	if f.scope.fatal {
+		t.Fatalf("%s", report)
+	} else {
+		t.Errorf("%s", report)
+	}
+}
@@ -0,0 +1,127 @@
+package must
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT records the failures reported to it instead of failing the test
+type recordingT struct {
+	*testing.T
+
+	mu     sync.Mutex
+	errors []string
+	fatals []string
+}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Fatalf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
+// TestWithT tests reporting failures to a test
+func TestWithT(t *testing.T) {
+
+	var gid uint64
+	t.Run("fatal", func(t *testing.T) {
+		rt := &recordingT{T: t}
+		WithT(rt)
+		gid = goroutineID()
+
+		Equal(1, 2, "numbers differ")
+
+		require.Len(t, rt.fatals, 1)
+		assert.Empty(t, rt.errors)
+		assert.Regexp(t, `^Equal: numbers differ: expected 1 to be equal to 2$`, rt.fatals[0])
+	})
+
+	// The scope is removed when the test finishes
+	assert.Empty(t, scopesOf(gid))
+
+	t.Run("errorf", func(t *testing.T) {
+		rt := &recordingT{T: t}
+		WithTErrorf(rt)
+
+		True(false, "first")
+		False(true, "second")
+
+		assert.Empty(t, rt.fatals)
+		assert.Len(t, rt.errors, 2)
+	})
+
+	t.Run("nested policy", func(t *testing.T) {
+		rt := &recordingT{T: t}
+		WithT(rt)
+
+		// A policy installed inside the test scope takes precedence
+		assert.Panics(t, func() {
+			WithPolicy(PolicyPanic, func() { True(false, "should panic") })
+		})
+		assert.Empty(t, rt.fatals)
+	})
+}
+
+// TestWithTParallel tests that parallel tests each report to their own scope
+func TestWithTParallel(t *testing.T) {
+
+	for i := range 16 {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+
+			rt := &recordingT{T: t}
+			WithT(rt)
+
+			Equal(i, -1, fmt.Sprintf("test %d", i))
+
+			require.Len(t, rt.fatals, 1)
+			assert.Contains(t, rt.fatals[0], fmt.Sprintf("test %d: expected %d to be equal to -1", i, i))
+		})
+	}
+}
+
+// TestWithTCallSite tests that go test attributes reports to the caller of the assertion.
+// It runs itself in a subprocess with a real *testing.T and checks the output.
+func TestWithTCallSite(t *testing.T) {
+
+	if os.Getenv("MUST_TEST_CALL_SITE") == "1" {
+		WithTErrorf(t)
+		a, b := 1, 1
+		_, _, line, _ := runtime.Caller(0)
+		fmt.Printf("call site: testing_test.go:%d\n", line+2)
+		Equal(1, 2, "numbers differ")
+		FileExists("/path/that/does/not/exist", "missing file")
+		PointsToSame(&a, &b, "different pointers")
+		MapAll(map[int]int{1: -1}, func(_, v int) bool { return v > 0 }, "negative")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestWithTCallSite$", "-test.v")
+	cmd.Env = append(os.Environ(), "MUST_TEST_CALL_SITE=1")
+	output, err := cmd.CombinedOutput()
+	require.Error(t, err, "the subprocess test should fail")
+
+	match := regexp.MustCompile(`call site: testing_test\.go:(\d+)`).FindSubmatch(output)
+	require.NotNil(t, match, string(output))
+	var line int
+	_, _ = fmt.Sscan(string(match[1]), &line)
+
+	assert.Contains(t, string(output), fmt.Sprintf("testing_test.go:%d: Equal: numbers differ: expected 1 to be equal to 2", line))
+	assert.Contains(t, string(output), fmt.Sprintf("testing_test.go:%d: FileExists: missing file:", line+1))
+	assert.Contains(t, string(output), fmt.Sprintf("testing_test.go:%d: PointsToSame: different pointers:", line+2))
+	assert.Contains(t, string(output), fmt.Sprintf("testing_test.go:%d: MapAll: negative:", line+3))
+}
@@ -0,0 +1,210 @@
+package must
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLCSCells bounds the size of the table used to align changed lines.
+// Larger changes are reported as a removal of all expected lines followed by all actual lines.
+const maxLCSCells = 4 << 20
+
+// EqualText checks if the given text is equal to the expected text and panics if it is not.
+// The failure details contain a unified diff of the two texts with context lines.
+// Carriage returns and trailing whitespace are made visible, and the size of the diff is
+// limited by the Context, MaxLines and MaxLineLength diff options.
+func EqualText(expected, value string, message string) {
+	if expected != value {
+		testHelper().Helper()
+		abortValues(message, "expected texts to be equal:\n"+textDiff(expected, value, currentDiffOptions()), expected, value)
+	}
+}
+
+// EqualBytes checks if the given byte slice is equal to the expected one and panics if it is not.
+// Valid UTF-8 input is diffed as text like EqualText, binary input is diffed as a hex dump.
+func EqualBytes(expected, value []byte, message string) {
+	if bytes.Equal(expected, value) {
+		return
+	}
+	opts := currentDiffOptions()
+	if utf8.Valid(expected) && utf8.Valid(value) {
+		testHelper().Helper()
+		abortValues(message, "expected bytes to be equal:\n"+textDiff(string(expected), string(value), opts), expected, value)
+		return
+	}
+	testHelper().Helper()
+	abortValues(message, "expected bytes to be equal:\n"+textDiff(hex.Dump(expected), hex.Dump(value), opts), expected, value)
+}
+
+// lineOp is a line of a diff: kept (' '), removed from the expected text ('-') or added in the actual text ('+').
+type lineOp struct {
+	kind byte
+	line string
+	a, b int // Index of the line in the expected and the actual text
+}
+
+// textDiff renders a unified diff of two texts.
+func textDiff(expected, actual string, opts DiffOptions) string {
+	var out strings.Builder
+	if strings.ReplaceAll(expected, "\r\n", "\n") == strings.ReplaceAll(actual, "\r\n", "\n") {
+		out.WriteString("(texts differ only in line endings, \\r is shown as ␍)\n")
+	}
+	out.WriteString("--- expected\n+++ actual")
+
+	ops := diffLines(splitLines(expected), splitLines(actual))
+	written, truncated := 0, false
+	for _, hunk := range hunks(ops, opts.Context) {
+		if truncated {
+			break
+		}
+		first := ops[hunk[0]]
+		aCount, bCount := 0, 0
+		for _, op := range ops[hunk[0]:hunk[1]] {
+			if op.kind != '+' {
+				aCount++
+			}
+			if op.kind != '-' {
+				bCount++
+			}
+		}
+		fmt.Fprintf(&out, "\n@@ -%d,%d +%d,%d @@", first.a+1, aCount, first.b+1, bCount)
+
+		for _, op := range ops[hunk[0]:hunk[1]] {
+			if opts.MaxLines > 0 && written >= opts.MaxLines {
+				truncated = true
+				break
+			}
+			line, newline := strings.CutSuffix(op.line, "\n")
+			out.WriteString("\n")
+			out.WriteByte(op.kind)
+			out.WriteString(visualizeLine(line, opts.MaxLineLength))
+			if !newline {
+				out.WriteString("\n\\ No newline at end of text")
+			}
+			written++
+		}
+	}
+	if truncated {
+		fmt.Fprintf(&out, "\n... (diff truncated after %d lines)", opts.MaxLines)
+	}
+	return out.String()
+}
+
+// splitLines splits s after each newline, keeping the newlines.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines aligns two lists of lines and returns the edit operations turning a into b.
+func diffLines(a, b []string) []lineOp {
+	var ops []lineOp
+
+	// Common prefix and suffix are kept as is
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	for i := range prefix {
+		ops = append(ops, lineOp{' ', a[i], i, i})
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	i, j := 0, 0
+	if len(ma)*len(mb) <= maxLCSCells {
+		// lcs[i][j] is the length of the longest common subsequence of ma[i:] and mb[j:]
+		width := len(mb) + 1
+		lcs := make([]int32, (len(ma)+1)*width)
+		for i := len(ma) - 1; i >= 0; i-- {
+			for j := len(mb) - 1; j >= 0; j-- {
+				if ma[i] == mb[j] {
+					lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+				} else {
+					lcs[i*width+j] = max(lcs[(i+1)*width+j], lcs[i*width+j+1])
+				}
+			}
+		}
+		for i < len(ma) && j < len(mb) {
+			switch {
+			case ma[i] == mb[j]:
+				ops = append(ops, lineOp{' ', ma[i], prefix + i, prefix + j})
+				i++
+				j++
+			case lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+				ops = append(ops, lineOp{'-', ma[i], prefix + i, prefix + j})
+				i++
+			default:
+				ops = append(ops, lineOp{'+', mb[j], prefix + i, prefix + j})
+				j++
+			}
+		}
+	}
+	for ; i < len(ma); i++ {
+		ops = append(ops, lineOp{'-', ma[i], prefix + i, prefix + j})
+	}
+	for ; j < len(mb); j++ {
+		ops = append(ops, lineOp{'+', mb[j], prefix + i, prefix + j})
+	}
+
+	for k := range suffix {
+		ops = append(ops, lineOp{' ', a[len(a)-suffix+k], len(a) - suffix + k, len(b) - suffix + k})
+	}
+	return ops
+}
+
+// hunks groups changed operations with up to context unchanged lines around them.
+// Each hunk is a half-open range of indices into ops.
+func hunks(ops []lineOp, context int) [][2]int {
+	var result [][2]int
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		start, end := max(i-context, 0), min(i+context+1, len(ops))
+		if n := len(result); n > 0 && start <= result[n-1][1] {
+			result[n-1][1] = max(result[n-1][1], end)
+		} else {
+			result = append(result, [2]int{start, end})
+		}
+	}
+	return result
+}
+
+// visualizeLine makes carriage returns and trailing whitespace visible and truncates long lines.
+func visualizeLine(line string, maxLength int) string {
+	trimmed := strings.TrimRight(line, " \t\r")
+	trailing := line[len(trimmed):]
+
+	var b strings.Builder
+	b.WriteString(strings.ReplaceAll(trimmed, "\r", "␍"))
+	for _, r := range trailing {
+		switch r {
+		case ' ':
+			b.WriteString("·")
+		case '\t':
+			b.WriteString("→")
+		case '\r':
+			b.WriteString("␍")
+		}
+	}
+	return truncateRunes(b.String(), maxLength)
+}
+
+// truncateRunes shortens s to at most n runes, marking the cut with an ellipsis. n <= 0 means no limit.
+func truncateRunes(s string, n int) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n]) + fmt.Sprintf("… (%d more characters)", len(runes)-n)
+}
@@ -0,0 +1,112 @@
+package must
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEqualText tests the EqualText function
+func TestEqualText(t *testing.T) {
+
+	// Success case
+	EqualText("a\nb\n", "a\nb\n", "should not panic")
+
+	// Failure cases
+	t.Run("changed line", func(t *testing.T) {
+		var expected, actual []string
+		for i := range 20 {
+			expected = append(expected, fmt.Sprintf("line %d", i))
+			actual = append(actual, fmt.Sprintf("line %d", i))
+		}
+		actual[10] = "line ten"
+
+		f := recoverFailure(t, func() {
+			EqualText(strings.Join(expected, "\n")+"\n", strings.Join(actual, "\n")+"\n", "texts differ")
+		})
+		assert.Equal(t, "EqualText", f.Assertion)
+		assert.Equal(t, strings.Join([]string{
+			"expected texts to be equal:",
+			"--- expected",
+			"+++ actual",
+			"@@ -8,7 +8,7 @@",
+			" line 7",
+			" line 8",
+			" line 9",
+			"-line 10",
+			"+line ten",
+			" line 11",
+			" line 12",
+			" line 13",
+		}, "\n"), f.Details)
+	})
+
+	t.Run("separate hunks", func(t *testing.T) {
+		expected := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+		actual := "A\nb\nc\nd\ne\nf\ng\nh\ni\nJ\n"
+
+		f := recoverFailure(t, func() { EqualText(expected, actual, "texts differ") })
+		assert.Contains(t, f.Details, "@@ -1,4 +1,4 @@\n-a\n+A\n b\n")
+		assert.Contains(t, f.Details, "@@ -7,4 +7,4 @@\n g\n h\n i\n-j\n+J")
+	})
+
+	t.Run("inserted and removed lines", func(t *testing.T) {
+		f := recoverFailure(t, func() { EqualText("a\nb\nc\n", "a\nx\nc\nd\n", "texts differ") })
+		assert.Contains(t, f.Details, " a\n-b\n+x\n c\n+d")
+	})
+
+	t.Run("whitespace", func(t *testing.T) {
+		f := recoverFailure(t, func() { EqualText("a\nb\n", "a \nb\t\n", "texts differ") })
+		assert.Contains(t, f.Details, "+a·\n")
+		assert.Contains(t, f.Details, "+b→")
+	})
+
+	t.Run("line endings", func(t *testing.T) {
+		f := recoverFailure(t, func() { EqualText("a\nb\n", "a\r\nb\r\n", "texts differ") })
+		assert.Contains(t, f.Details, "differ only in line endings")
+		assert.Contains(t, f.Details, "+a␍\n+b␍")
+	})
+
+	t.Run("missing final newline", func(t *testing.T) {
+		f := recoverFailure(t, func() { EqualText("a\nb\n", "a\nb", "texts differ") })
+		assert.Contains(t, f.Details, "+b\n\\ No newline at end of text")
+	})
+
+	t.Run("truncation", func(t *testing.T) {
+		opts := DefaultDiffOptions
+		opts.MaxLines = 6
+		opts.MaxLineLength = 5
+		prev := SetDiffOptions(opts)
+		defer SetDiffOptions(prev)
+
+		f := recoverFailure(t, func() {
+			EqualText("1\n2\n3\n4\n5\n", "one thousand\ntwo\nthree\nfour\nfive\n", "texts differ")
+		})
+		assert.Contains(t, f.Details, "+one t… (7 more characters)")
+		assert.Contains(t, f.Details, "... (diff truncated after 6 lines)")
+		assert.NotContains(t, f.Details, "two")
+	})
+}
+
+// TestEqualBytes tests the EqualBytes function
+func TestEqualBytes(t *testing.T) {
+
+	// Success case
+	EqualBytes([]byte("abc"), []byte("abc"), "should not panic")
+	EqualBytes(nil, []byte{}, "should not panic")
+
+	// Failure cases
+	t.Run("text", func(t *testing.T) {
+		f := recoverFailure(t, func() { EqualBytes([]byte("a\nb\n"), []byte("a\nc\n"), "bytes differ") })
+		assert.Equal(t, "EqualBytes", f.Assertion)
+		assert.Contains(t, f.Details, "-b\n+c")
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		f := recoverFailure(t, func() { EqualBytes([]byte{0xff, 0x00, 0x01}, []byte{0xff, 0x00, 0x02}, "bytes differ") })
+		assert.Contains(t, f.Details, "-00000000  ff 00 01")
+		assert.Contains(t, f.Details, "+00000000  ff 00 02")
+	})
+}
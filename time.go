@@ -0,0 +1,126 @@
+package must
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Times in failure details are formatted as RFC 3339 with nanoseconds, differences as durations.
+// The comparisons use the monotonic clock reading when both times have one, as the time package does.
+
+// Clock provides the current time to NotInFuture and NotExpired.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock reading the system time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// clockBox holds a Clock so it can be stored atomically.
+type clockBox struct{ Clock }
+
+var clock atomic.Pointer[clockBox]
+
+func init() {
+	clock.Store(&clockBox{systemClock{}})
+}
+
+// SetClock sets the process-wide clock used by the assertions relative to the current time
+// and returns the previous one. A nil clock restores the system clock.
+func SetClock(c Clock) Clock {
+	if c == nil {
+		c = systemClock{}
+	}
+	return clock.Swap(&clockBox{c}).Clock
+}
+
+// now returns the current time of the clock in effect.
+func now() time.Time {
+	return clock.Load().Now()
+}
+
+// WithinDuration checks if value is at most delta away from expected, in either direction, and panics if it is not.
+// Use it instead of Equal to compare times, which also compares locations and monotonic clock readings.
+func WithinDuration(expected, value time.Time, delta time.Duration, message string) {
+	diff := value.Sub(expected)
+	if diff < -delta || diff > delta {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to be within %v of %s, difference is %v", formatTime(value), delta, formatTime(expected), diff), expected, value)
+	}
+}
+
+// Before checks if value is before threshold and panics if it is not.
+func Before(value, threshold time.Time, message string) {
+	if !value.Before(threshold) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to be before %s, it is %v later", formatTime(value), formatTime(threshold), value.Sub(threshold)), threshold, value)
+	}
+}
+
+// After checks if value is after threshold and panics if it is not.
+func After(value, threshold time.Time, message string) {
+	if !value.After(threshold) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to be after %s, it is %v earlier", formatTime(value), formatTime(threshold), threshold.Sub(value)), threshold, value)
+	}
+}
+
+// NotZeroTime checks if value is not the zero time and panics if it is.
+func NotZeroTime(value time.Time, message string) {
+	if value.IsZero() {
+		testHelper().Helper()
+		abort(message, "expected a non-zero time, got the zero time")
+	}
+}
+
+// InLocation checks if value is in the location with the same name as loc and panics if it is not.
+func InLocation(value time.Time, loc *time.Location, message string) {
+	if value.Location().String() != loc.String() {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to be in location %s, got %s", formatTime(value), loc, value.Location()), loc.String(), value.Location().String())
+	}
+}
+
+// DurationBetween checks if d is within [low, high] and panics if it is not.
+func DurationBetween(d, low, high time.Duration, message string) {
+	if d < low || d > high {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %v to be between %v and %v", d, low, high), [2]time.Duration{low, high}, d)
+	}
+}
+
+// Monotonic checks if times are in non-decreasing order and panics at the first time that is before its predecessor.
+func Monotonic(times []time.Time, message string) {
+	for i := 1; i < len(times); i++ {
+		if times[i].Before(times[i-1]) {
+			testHelper().Helper()
+			abortValues(message, fmt.Sprintf("expected times to be in order, [%d] = %s is %v before [%d] = %s",
+				i, formatTime(times[i]), times[i-1].Sub(times[i]), i-1, formatTime(times[i-1])), nil, times)
+			return
+		}
+	}
+}
+
+// NotInFuture checks if value is not after the current time of the clock set with SetClock and panics if it is.
+func NotInFuture(value time.Time, message string) {
+	if current := now(); value.After(current) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to not be in the future, it is %v after %s", formatTime(value), value.Sub(current), formatTime(current)), current, value)
+	}
+}
+
+// NotExpired checks if expiry is after the current time of the clock set with SetClock and panics if it is not.
+func NotExpired(expiry time.Time, message string) {
+	if current := now(); !expiry.After(current) {
+		testHelper().Helper()
+		abortValues(message, fmt.Sprintf("expected %s to not be expired, it expired %v before %s", formatTime(expiry), current.Sub(expiry), formatTime(current)), current, expiry)
+	}
+}
+
+// formatTime formats a time for failure details.
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
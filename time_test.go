@@ -0,0 +1,125 @@
+package must
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fixedClock is a Clock that always returns the same time
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+var noon = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// TestWithinDuration tests the WithinDuration function
+func TestWithinDuration(t *testing.T) {
+
+	// Success cases
+	WithinDuration(noon, noon.Add(time.Second), time.Second, "should not panic")
+	WithinDuration(noon, noon.Add(-time.Second), time.Second, "should not panic")
+	WithinDuration(noon, noon.In(time.FixedZone("CEST", 2*60*60)), 0, "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() { WithinDuration(noon, noon.Add(1500*time.Millisecond), time.Second, "too late") })
+	assert.Equal(t, "WithinDuration", f.Assertion)
+	assert.Equal(t, "expected 2024-05-01T12:00:01.5Z to be within 1s of 2024-05-01T12:00:00Z, difference is 1.5s", f.Details)
+	assert.Equal(t, noon, f.Expected)
+}
+
+// TestBeforeAfter tests the Before and After functions
+func TestBeforeAfter(t *testing.T) {
+
+	// Success cases
+	Before(noon, noon.Add(time.Nanosecond), "should not panic")
+	After(noon.Add(time.Nanosecond), noon, "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { Before(noon.Add(time.Minute), noon, "too late") })
+	assert.Equal(t, "Before", f.Assertion)
+	assert.Equal(t, "expected 2024-05-01T12:01:00Z to be before 2024-05-01T12:00:00Z, it is 1m0s later", f.Details)
+
+	f = recoverFailure(t, func() { After(noon, noon, "same time") })
+	assert.Equal(t, "After", f.Assertion)
+	assert.Equal(t, "expected 2024-05-01T12:00:00Z to be after 2024-05-01T12:00:00Z, it is 0s earlier", f.Details)
+}
+
+// TestNotZeroTime tests the NotZeroTime function
+func TestNotZeroTime(t *testing.T) {
+
+	// Success case
+	NotZeroTime(noon, "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() { NotZeroTime(time.Time{}, "zero") })
+	assert.Equal(t, "NotZeroTime", f.Assertion)
+	assert.Equal(t, "expected a non-zero time, got the zero time", f.Details)
+}
+
+// TestInLocation tests the InLocation function
+func TestInLocation(t *testing.T) {
+
+	// Success cases
+	InLocation(noon, time.UTC, "should not panic")
+	InLocation(noon.In(time.FixedZone("CEST", 2*60*60)), time.FixedZone("CEST", 2*60*60), "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() { InLocation(noon, time.Local, "not local") })
+	assert.Equal(t, "InLocation", f.Assertion)
+	assert.Equal(t, "expected 2024-05-01T12:00:00Z to be in location Local, got UTC", f.Details)
+}
+
+// TestDurationBetween tests the DurationBetween function
+func TestDurationBetween(t *testing.T) {
+
+	// Success cases
+	DurationBetween(time.Second, time.Second, time.Minute, "should not panic")
+	DurationBetween(time.Minute, time.Second, time.Minute, "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() { DurationBetween(2*time.Hour, time.Second, time.Minute, "too slow") })
+	assert.Equal(t, "DurationBetween", f.Assertion)
+	assert.Equal(t, "expected 2h0m0s to be between 1s and 1m0s", f.Details)
+	assert.Equal(t, 2*time.Hour, f.Actual)
+}
+
+// TestMonotonic tests the Monotonic function
+func TestMonotonic(t *testing.T) {
+
+	// Success cases
+	Monotonic([]time.Time{noon, noon, noon.Add(time.Second)}, "should not panic")
+	Monotonic(nil, "should not panic")
+
+	// Failure case
+	f := recoverFailure(t, func() { Monotonic([]time.Time{noon, noon.Add(time.Hour), noon.Add(time.Minute)}, "out of order") })
+	assert.Equal(t, "Monotonic", f.Assertion)
+	assert.Equal(t, "expected times to be in order, [2] = 2024-05-01T12:01:00Z is 59m0s before [1] = 2024-05-01T13:00:00Z", f.Details)
+}
+
+// TestClock tests the NotInFuture and NotExpired functions with an injected clock
+func TestClock(t *testing.T) {
+
+	prev := SetClock(fixedClock(noon))
+	defer SetClock(prev)
+
+	// Success cases
+	NotInFuture(noon, "should not panic")
+	NotInFuture(noon.Add(-time.Hour), "should not panic")
+	NotExpired(noon.Add(time.Second), "should not panic")
+
+	// Failure cases
+	f := recoverFailure(t, func() { NotInFuture(noon.Add(time.Minute), "clock skew") })
+	assert.Equal(t, "NotInFuture", f.Assertion)
+	assert.Equal(t, "expected 2024-05-01T12:01:00Z to not be in the future, it is 1m0s after 2024-05-01T12:00:00Z", f.Details)
+
+	f = recoverFailure(t, func() { NotExpired(noon.Add(-time.Hour), "token expired") })
+	assert.Equal(t, "NotExpired", f.Assertion)
+	assert.Equal(t, "expected 2024-05-01T11:00:00Z to not be expired, it expired 1h0m0s before 2024-05-01T12:00:00Z", f.Details)
+
+	// A nil clock restores the system clock
+	SetClock(nil)
+	NotExpired(time.Now().Add(time.Hour), "should not panic")
+	assert.IsType(t, systemClock{}, SetClock(fixedClock(noon)))
+}